@@ -20,11 +20,26 @@ var (
 	EmptyWorkingDirectory = errors.New(`Empty working directory, couldn't found any file`)
 	InvalidSettingsData   = errors.New(`Invalid settings data, cannot complete operation`)
 
+	NotAvailableForLocal        = errors.New(`This functionality isn't available for local service, use it with the --firebase(-f) flag`)
 	NotAvailableForFirebase     = errors.New(`This functionality isn't available for firebase service`)
 	InvalidFirebaseProjectID    = errors.New(`Provided firebase-project-id is invalid(or empty)`)
 	FirebaseServiceKeyNotExists = errors.New(`Firebase service key file doesn't exists at given path`)
 	InvalidFirebaseCollection   = errors.New(`Provided firebase-collection-id is invalid`)
+	InvalidFirebaseLayout       = errors.New(`Provided firebase layout is invalid, it must be "nested" or "flat"`)
 	InvalidPathForAct           = errors.New(`Generated or provided path is invalid for this action`)
+	UnfinishedEdit              = errors.New(`There is an unfinished edit of this note, run recover command to resolve it`)
+	EditConflict                = errors.New(`Remote note was changed by someone else after it was read, cannot overwrite it`)
+	NoMirror                    = errors.New(`Firebase is unreachable, and there is no cached data of collection`)
+	CannotWatchOffline          = errors.New(`Firebase is unreachable, cannot watch remote changes`)
+	InvalidOperation            = errors.New(`Queued operation is invalid, it cannot be replayed`)
+	OperationConflict           = errors.New(`Remote note was changed after the operation was queued`)
+	InvalidResolution           = errors.New(`Provided conflict resolution is invalid, it must be "mine", "theirs" or "copy"`)
+	InvalidFrontMatter          = errors.New(`Front matter of note is invalid, it must be a YAML mapping`)
+	InvalidMetaKey              = errors.New(`Provided metadata key is invalid(or empty)`)
+	NoTaggedNotes               = errors.New(`There are no notes with provided tags`)
+	InvalidQueryName            = errors.New(`Provided query name is invalid, it could contain only letters, digits, "_" and "-"`)
+	InvalidGraphFormat          = errors.New(`Provided graph format is invalid, it must be "dot", "mermaid" or "json"`)
+	InvalidJournalDate          = errors.New(`Provided date is invalid, it must be like "2026-10-18", "today", "yesterday" or "-3"(days ago)`)
 )
 
 // NotExists returns a formatted error message as data-not-exists error.
@@ -45,9 +60,36 @@ func AlreadyExists(path, node string) error {
 	return errors.New(msg)
 }
 
+// InvalidQuery returns a formatted error message of invalid query, with the [reason] of it.
+func InvalidQuery(query, reason string) error {
+	msg := fmt.Sprintf("Invalid query %q: %v", query, reason)
+	return errors.New(msg)
+}
+
 // CannotDoSth generates a extre informative error via migrating with actual error.
 func CannotDoSth(act, doc string, err error) error {
 	return errors.New(
 		fmt.Sprintf("Cannot %v %v | %v", act, doc, err.Error()),
 	)
 }
+
+// ChangesQueryFailed returns a formatted error message of failed changes query,
+// which is replaced by a full scan of collection.
+func ChangesQueryFailed(err error) error {
+	msg := fmt.Sprintf("Cannot query changes by modification time, fell back to a full scan(enable the collection group index of \"updated_at\" field) | %v", err.Error())
+	return errors.New(msg)
+}
+
+// SyncConflict returns a formatted error message of node, that was changed at both
+// local and remote since the last sync. [copy] is the title of local copy of remote
+// version, which is empty if remote version isn't saved yet.
+func SyncConflict(title, copy string) error {
+	msg := fmt.Sprintf("%v was changed at both local and remote since the last sync", title)
+	if len(copy) > 0 {
+		msg += fmt.Sprintf(", remote version is saved as %v", copy)
+	} else {
+		msg += ", fetch to save remote version next to it"
+	}
+
+	return errors.New(msg)
+}
@@ -93,3 +93,44 @@ func TestCannotDoSth(t *testing.T) {
 	}
 
 }
+
+func TestChangesQueryFailed(t *testing.T) {
+	tests := []struct {
+		err, expected error
+	}{
+		{
+			err:      errors.New("sww"),
+			expected: errors.New(`Cannot query changes by modification time, fell back to a full scan(enable the collection group index of "updated_at" field) | sww`),
+		},
+	}
+
+	for _, td := range tests {
+		got := assets.ChangesQueryFailed(td.err)
+		if got.Error() != td.expected.Error() {
+			t.Errorf("Sum of ChangesQueryFailed was different: Want: %v, Got: %v", td.expected, got)
+		}
+	}
+}
+
+func TestSyncConflict(t *testing.T) {
+	tests := []struct {
+		title, copy string
+		expected    error
+	}{
+		{
+			title: "todo.md", copy: "",
+			expected: errors.New("todo.md was changed at both local and remote since the last sync, fetch to save remote version next to it"),
+		},
+		{
+			title: "todo.md", copy: "todo.conflict-20211208144312.md",
+			expected: errors.New("todo.md was changed at both local and remote since the last sync, remote version is saved as todo.conflict-20211208144312.md"),
+		},
+	}
+
+	for _, td := range tests {
+		got := assets.SyncConflict(td.title, td.copy)
+		if got.Error() != td.expected.Error() {
+			t.Errorf("Sum of SyncConflict was different: Want: %v, Got: %v", td.expected, got)
+		}
+	}
+}
@@ -28,6 +28,30 @@ func ChooseRemotePrompt(services []string) *survey.Select {
 	}
 }
 
+// ChooseLayoutPrompt is a prompt interface for tui firebase layout choosing bar.
+func ChooseLayoutPrompt(current string) *survey.Select {
+	return &survey.Select{
+		Message: "Choose document layout:",
+		Options: []string{"nested", "flat"},
+		Default: current,
+		Help:    "nested: folders keep their nodes in sub collections | flat: every node is a top-level document",
+	}
+}
+
+// RecoverActionPrompt is a prompt interface for tui unfinished-edit recovering action choosing bar.
+var RecoverActionPrompt = &survey.Select{
+	Message: "Choose an action:",
+	Options: []string{"push", "edit", "discard"},
+	Help:    "push: write edits back to remote | edit: continue editing | discard: remove edits",
+}
+
+// ConflictResolutionPrompt is a prompt interface for tui edit-conflict resolution choosing bar.
+var ConflictResolutionPrompt = &survey.Select{
+	Message: "Remote note was changed by someone else, choose an action:",
+	Options: []string{"diff", "mine", "theirs", "copy"},
+	Help:    "diff: show changes | mine: overwrite remote | theirs: drop my edits | copy: save my edits as a new note",
+}
+
 // CreatePromptQuestion is a question list for create command.
 var CreatePromptQuestion = []*survey.Question{
 	{
@@ -59,6 +83,11 @@ func NewNamePrompt(d string) *survey.Input {
 	return &survey.Input{Message: "New name: ", Default: d}
 }
 
+// TemplateFieldPrompt is a input prompt for custom variables of note templates.
+func TemplateFieldPrompt(name, d string) *survey.Input {
+	return &survey.Input{Message: name, Help: "Value of {{" + name + "}} at template", Default: d}
+}
+
 // MoveNotesPrompt is a confirm prompt for setting's move-note functionality.
 var MoveNotesPrompt = &survey.Confirm{
 	Message: "Move notes",
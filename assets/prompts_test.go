@@ -104,6 +104,14 @@ func TestChooseRemotePrompt(t *testing.T) {
 	}
 }
 
+func TestChooseLayoutPrompt(t *testing.T) {
+	got := assets.ChooseLayoutPrompt("flat")
+
+	if got.Default != "flat" || len(got.Options) != 2 {
+		t.Errorf("Sum of ChooseLayoutPrompt was different: Got: %v", got)
+	}
+}
+
 func TestNewNamePrompt(t *testing.T) {
 	tests := []struct {
 		testname     string
@@ -130,3 +138,33 @@ func TestNewNamePrompt(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateFieldPrompt(t *testing.T) {
+	tests := []struct {
+		testname     string
+		name         string
+		defaultValue string
+		expected     survey.Input
+	}{
+		{
+			testname:     "should generate template-field-prompt properly",
+			name:         "project",
+			defaultValue: "nt",
+			expected: survey.Input{
+				Message: "project",
+				Help:    "Value of {{project}} at template",
+				Default: "nt",
+			},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testname, func(t *testing.T) {
+			got := assets.TemplateFieldPrompt(td.name, td.defaultValue)
+
+			if got.Message != td.expected.Message || got.Help != td.expected.Help || got.Default != td.expected.Default {
+				t.Errorf("Sum of TemplateFieldPrompt was different: Want: %v | Got: %v", td.expected, got)
+			}
+		})
+	}
+}
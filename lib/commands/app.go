@@ -7,8 +7,12 @@
 package commands
 
 import (
+	"fmt"
 	"os"
+	"path"
+	"time"
 
+	"github.com/fatih/color"
 	"github.com/insolite-dev/nt/assets"
 	"github.com/insolite-dev/nt/lib/models"
 	"github.com/insolite-dev/nt/lib/services"
@@ -56,6 +60,7 @@ var appCommand = &cobra.Command{
 		assets.MinimalisticBanner,
 		assets.ShortSlog,
 	),
+	PersistentPostRun: func(cmd *cobra.Command, args []string) { refreshMirror() },
 }
 
 // initCommands initializes all sub-commands of application.
@@ -81,6 +86,19 @@ func initCommands() {
 	initCutCommand()
 	initRemoteCommand()
 	initWhereCommand()
+	initDoctorCommand()
+	initRecoverCommand()
+	initQueueCommand()
+	initSyncCommand()
+	initWatchCommand()
+	initStatusCommand()
+	initMetaCommand()
+	initTagCommand()
+	initQueryCommand()
+	initLinksCommand()
+	initGraphCommand()
+	initJournalCommand()
+	initAppendCommand()
 }
 
 // ExecuteApp is a main function that app starts executing and working.
@@ -135,8 +153,8 @@ func setupLocalService() {
 func setupFirebaseService() {
 	loading.Start()
 
-	fireService = services.NewFirebaseService(stdargs, localService)
-	err := fireService.Init(nil)
+	fire := services.NewFirebaseService(stdargs, localService)
+	err := fire.Init(nil)
 
 	loading.Stop()
 
@@ -144,4 +162,102 @@ func setupFirebaseService() {
 		pkg.Alert(pkg.ErrorL, err.Error())
 		os.Exit(1)
 	}
+
+	fireService = fire
+	replayQueue(fire)
+}
+
+// refreshMirror keeps the local mirror of firebase service up to date, to be
+// able to view notes offline. Called after successful commands, see [succeed].
+func refreshMirror() {
+	fire, ok := fireService.(*services.FirebaseService)
+	if !ok || fire.Offline {
+		return
+	}
+
+	loading.Start()
+	_ = fire.RefreshMirror()
+	loading.Stop()
+}
+
+// succeed refreshes the mirror of firebase service, and alerts given
+// success [msg]. Alerting exits, so [appCommand]'s post-run isn't called.
+func succeed(msg string) {
+	refreshMirror()
+	pkg.Alert(pkg.SuccessL, msg)
+}
+
+// warnIfStale informs that data of current service is served from
+// its local mirror, because the remote is unreachable.
+func warnIfStale() {
+	fire, ok := service.(*services.FirebaseService)
+	if !ok || !fire.Offline {
+		return
+	}
+
+	if mirror := fire.Mirror(); mirror != nil {
+		pkg.Print(
+			fmt.Sprintf("\n Showing cached data, stale as of %v\n", mirror.RefreshedAt.Format(time.RFC1123)),
+			color.FgYellow,
+		)
+	}
+}
+
+// checkPatterns validates the path patterns of selective commands.
+func checkPatterns(patterns []string) {
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			pkg.Alert(pkg.ErrorL, fmt.Sprintf("Invalid path pattern %q: %v", p, err))
+		}
+	}
+}
+
+// addTagFlag adds a repeatable tag filter flag to [cmd].
+func addTagFlag(cmd *cobra.Command, tags *[]string, usage string) {
+	cmd.Flags().StringSliceVarP(tags, "tag", "t", nil, usage)
+}
+
+// taggedPatterns limits path [patterns] to the notes of [s], that have all of given [tags].
+// See [services.TaggedPatterns].
+func taggedPatterns(s services.ServiceRepo, patterns, tags []string) []string {
+	if len(tags) == 0 {
+		return patterns
+	}
+
+	loading.Start()
+	res, err := services.TaggedPatterns(s, patterns, tags)
+	loading.Stop()
+
+	if err == assets.NoTaggedNotes {
+		pkg.Alert(pkg.InfoL, err.Error())
+	} else if err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+	}
+
+	return res
+}
+
+// resetRetried clears the retried requests and warnings of firebase
+// service, to track only the requests of upcoming action.
+func resetRetried() {
+	if fire, ok := fireService.(*services.FirebaseService); ok {
+		fire.Retried = nil
+		fire.Warnings = nil
+	}
+}
+
+// printRetried logs the requests of firebase service, that were
+// failed by a transient error, but succeeded after retrying.
+func printRetried() {
+	if fire, ok := fireService.(*services.FirebaseService); ok && len(fire.Retried) > 0 {
+		pkg.PrintRetried(fire.Retried)
+	}
+}
+
+// printWarnings logs the degraded behaviours of firebase service,
+// like falling back to a full scan of collection.
+func printWarnings() {
+	if fire, ok := fireService.(*services.FirebaseService); ok && len(fire.Warnings) > 0 {
+		pkg.PrintWarnings(fire.Warnings)
+	}
 }
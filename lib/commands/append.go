@@ -0,0 +1,99 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/lib/services"
+	"github.com/insolite-dev/nt/pkg"
+	"github.com/spf13/cobra"
+)
+
+// appendCommand is a command model that used to add text to the end of note.
+var appendCommand = &cobra.Command{
+	Use:   "append <note> [text|-]",
+	Short: "Add text (or piped input) to the end of note, creating it if needed",
+	Run: func(cmd *cobra.Command, args []string) {
+		addToNote(args, false)
+	},
+}
+
+// prependCommand is a command model that used to add text to the start of note.
+var prependCommand = &cobra.Command{
+	Use:   "prepend <note> [text|-]",
+	Short: "Add text (or piped input) to the start of note, after its front matter",
+	Run: func(cmd *cobra.Command, args []string) {
+		addToNote(args, true)
+	},
+}
+
+// captureEntry is the value of entry flag, which decides
+// to add the text as a timestamped bullet entry.
+var captureEntry bool
+
+// initAppendCommand adds [appendCommand] and [prependCommand] to the [appCommand].
+func initAppendCommand() {
+	for _, cmd := range []*cobra.Command{appendCommand, prependCommand} {
+		cmd.Flags().BoolVarP(
+			&captureEntry, "entry", "e", false,
+			"Add the text as a timestamped bullet entry, like: - 2026-10-19 15:04 text",
+		)
+
+		appCommand.AddCommand(cmd)
+	}
+}
+
+// addToNote appends(or prepends) the text of arguments or piped input to the note.
+func addToNote(args []string, prepend bool) {
+	if len(args) == 0 {
+		pkg.Alert(pkg.ErrorL, "Provide a note to add text to")
+		return
+	}
+
+	// Input is read if it's piped, or explicitly requested via "-" text.
+	text := strings.Join(args[1:], " ")
+	if len(args) == 1 || text == "-" {
+		read := pkg.ReadPiped
+		if text == "-" {
+			read = pkg.ReadStdin
+		}
+
+		piped, ok := read(stdargs.Stdin)
+		if !ok {
+			pkg.Alert(pkg.ErrorL, "Provide a text to add, as an argument or piped input")
+			return
+		}
+
+		text = piped
+	}
+
+	if len(strings.TrimSpace(text)) == 0 {
+		pkg.Alert(pkg.ErrorL, "Provided text is empty")
+		return
+	}
+
+	if captureEntry {
+		text = models.CaptureEntry(text, time.Now())
+	}
+
+	determineService()
+
+	loading.Start()
+	_, err := services.AddToNote(service, args[0], text, prepend)
+	loading.Stop()
+
+	if err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+		return
+	}
+
+	succeed(fmt.Sprintf("Added to %v", args[0]))
+}
@@ -8,10 +8,12 @@ package commands
 
 import (
 	"os"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/insolite-dev/nt/assets"
 	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/lib/services"
 	"github.com/insolite-dev/nt/pkg"
 	"github.com/spf13/cobra"
 )
@@ -30,6 +32,9 @@ var providedFolderName string
 // providedContent is the value of created file, passwed from command.
 var providedContent string
 
+// providedTemplate is the name of template, that new note is instantiated from.
+var providedTemplate string
+
 // initCreateCommand adds it to the main application command.
 func initCreateCommand() {
 	createCommand.Flags().StringVarP(
@@ -39,7 +44,12 @@ func initCreateCommand() {
 
 	createCommand.Flags().StringVarP(
 		&providedContent, "content", "c", "",
-		"The content of your note file",
+		"The content of your note file, or - to read it from stdin",
+	)
+
+	createCommand.Flags().StringVarP(
+		&providedTemplate, "template", "t", "",
+		"The name of template (from "+models.TemplatesFolder+" folder) to fill the note with",
 	)
 
 	appCommand.AddCommand(createCommand)
@@ -85,8 +95,28 @@ func createAndFinish(title string) {
 		return
 	}
 
+	body := providedContent
+
+	// Read the body from piped input, like: kubectl get pods | nt create pods.txt
+	// Or from any input, if it's explicitly requested via "-" content.
+	piped := false
+	if providedContent == "-" {
+		body, piped = pkg.ReadStdin(stdargs.Stdin)
+	} else if len(providedContent) == 0 && len(providedTemplate) == 0 {
+		body, piped = pkg.ReadPiped(stdargs.Stdin)
+	}
+
+	if len(providedTemplate) > 0 {
+		if len(providedContent) > 0 {
+			pkg.Alert(pkg.ErrorL, "Provide either a template or a content, not both")
+			return
+		}
+
+		body = instantiateTemplate(providedTemplate, title, time.Now())
+	}
+
 	loading.Start()
-	note, err := service.Create(models.Note{Title: title, Body: providedContent})
+	note, err := services.CreateNote(service, models.Note{Title: title, Body: body})
 	loading.Stop()
 
 	if err != nil {
@@ -96,7 +126,7 @@ func createAndFinish(title string) {
 
 	// Ask for, open or not created note with editor.
 	var openNote bool
-	if providedContent == "" {
+	if providedContent == "" && !piped {
 		survey.AskOne(assets.OpenViaEditorPromt, &openNote)
 	}
 
@@ -108,3 +138,29 @@ func createAndFinish(title string) {
 		}
 	}
 }
+
+// instantiateTemplate fetches the template of [name], and fills its variables for the note of [title].
+// Built-in variables are generated for the time of [now], and custom ones are asked from user.
+func instantiateTemplate(name, title string, now time.Time) string {
+	loading.Start()
+	template, err := services.FindTemplate(service, name)
+	loading.Stop()
+
+	if err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+		return ""
+	}
+
+	values := services.TemplateValues(title, now)
+	for _, variable := range models.TemplateVariables(template.Body) {
+		if variable.IsBuiltIn() {
+			continue
+		}
+
+		value := variable.Default
+		survey.AskOne(assets.TemplateFieldPrompt(variable.Name, variable.Default), &value)
+		values[variable.Name] = value
+	}
+
+	return models.ExpandTemplate(template.Body, values)
+}
@@ -0,0 +1,82 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/insolite-dev/nt/lib/services"
+	"github.com/insolite-dev/nt/pkg"
+	"github.com/spf13/cobra"
+)
+
+// doctorCommand is a command model which used to detect
+// and repair inconsistencies of current service.
+var doctorCommand = &cobra.Command{
+	Use:     "doctor",
+	Aliases: []string{"check", "dr"},
+	Short:   "Detect and repair inconsistencies of service",
+	Run:     runDoctorCommand,
+}
+
+// doctorFix is the value of fix flag, which decides
+// to repair found problems, instead of only reporting them.
+var doctorFix bool
+
+// initDoctorCommand adds doctorCommand to main application command.
+func initDoctorCommand() {
+	doctorCommand.Flags().BoolVar(
+		&doctorFix, "fix", false,
+		"Repair found problems, that could be repaired automatically",
+	)
+
+	appCommand.AddCommand(doctorCommand)
+}
+
+// runDoctorCommand runs appropriate service doctor, and logs its report.
+func runDoctorCommand(cmd *cobra.Command, args []string) {
+	determineService()
+
+	loading.Start()
+	report, err := service.Doctor(doctorFix)
+	if err == nil {
+		services.DiagnoseLinks(service, report)
+	}
+	loading.Stop()
+
+	if err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+		return
+	}
+
+	if len(report.Diagnoses) == 0 {
+		pkg.Print("No problems found", color.FgHiGreen)
+		return
+	}
+
+	pkg.PrintDiagnoses(*report)
+
+	counts := []string{}
+	for kind, count := range report.Counts() {
+		counts = append(counts, fmt.Sprintf("%v %v", count, kind))
+	}
+
+	sort.Strings(counts)
+
+	fmt.Println()
+	pkg.Print(fmt.Sprintf(" Found: %v", strings.Join(counts, ", ")), color.FgHiWhite)
+
+	if unresolved := report.Unresolved(); unresolved > 0 {
+		pkg.Alert(pkg.InfoL, fmt.Sprintf("%v of %v problems are unresolved", unresolved, len(report.Diagnoses)))
+		return
+	}
+
+	succeed(fmt.Sprintf("Repaired %v problems", len(report.Diagnoses)))
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/insolite-dev/nt/assets"
 	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/lib/services"
 	"github.com/insolite-dev/nt/pkg"
 	"github.com/spf13/cobra"
 )
@@ -65,7 +66,15 @@ func editAndFinish(note models.Node) {
 		return
 	}
 
-	if err := service.Open(note); err != nil {
+	err := service.Open(note)
+
+	// Remote note could be changed by someone else while editing.
+	if fire, ok := service.(*services.FirebaseService); ok && err == assets.EditConflict {
+		id := services.EditID(fire.Type(), fire.Config.FirePath(), note.Title)
+		err = resolveConflict(fire, fire.Journal()[id])
+	}
+
+	if err != nil {
 		pkg.Alert(pkg.ErrorL, err.Error())
 	}
 }
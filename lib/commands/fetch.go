@@ -19,17 +19,23 @@ import (
 )
 
 var fetchCommand = &cobra.Command{
-	Use:     "fetch",
+	Use:     "fetch [path or pattern...]",
 	Aliases: []string{"pull"},
 	Short:   "Fetch creates a clone of each node from [Y] service to [X] service",
 	Run:     runFetchCommand,
 }
 
+// fetchTags is the value of tag flag, which limits the command to tagged notes.
+var fetchTags []string
+
 func initFetchCommand() {
+	addTagFlag(fetchCommand, &fetchTags, "Fetch only the notes that have this tag (could be repeated)")
+
 	appCommand.AddCommand(fetchCommand)
 }
 
 func runFetchCommand(cmd *cobra.Command, args []string) {
+	checkPatterns(args)
 	determineService()
 	loading.Start()
 
@@ -58,16 +64,21 @@ func runFetchCommand(cmd *cobra.Command, args []string) {
 	}
 
 	selectedService := serviceFromType(selected, true)
+	args = taggedPatterns(selectedService, args, fetchTags)
+
+	resetRetried()
 
 	loading.Start()
-	fetchedNodes, errs := service.Fetch(selectedService)
+	fetchedNodes, errs := service.Fetch(selectedService, args)
 	loading.Stop()
 
+	printWarnings()
 	if len(fetchedNodes) == 0 && len(errs) == 0 {
 		pkg.Print("Already up to date", color.FgHiGreen)
 		return
 	}
 
+	printRetried()
 	pkg.PrintErrors("fetch", errs)
-	pkg.Alert(pkg.SuccessL, fmt.Sprintf("Fetched %v nodes", len(fetchedNodes)))
+	succeed(fmt.Sprintf("Fetched %v nodes", len(fetchedNodes)))
 }
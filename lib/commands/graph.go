@@ -0,0 +1,89 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/lib/services"
+	"github.com/insolite-dev/nt/pkg"
+	"github.com/spf13/cobra"
+)
+
+// graphCommand is a command model that used to export the graph of notes.
+var graphCommand = &cobra.Command{
+	Use:   "graph",
+	Short: "Export the graph of note links and folders (as DOT, Mermaid or JSON)",
+	Run:   runGraphCommand,
+}
+
+// Values of graph command flags.
+var (
+	graphFormat  string
+	graphRoot    string
+	graphDepth   int
+	graphOrphans bool
+)
+
+// initGraphCommand adds [graphCommand] to the [appCommand].
+func initGraphCommand() {
+	graphCommand.Flags().StringVar(
+		&graphFormat, "format", models.DOTFormat,
+		"Format of exported graph: dot, mermaid or json",
+	)
+	graphCommand.Flags().StringVarP(
+		&graphRoot, "root", "r", "",
+		"Export only the part of graph, that's reachable from given note or folder",
+	)
+	graphCommand.Flags().IntVarP(
+		&graphDepth, "depth", "d", 0,
+		"Maximum number of edges from the root (0 means no limit)",
+	)
+	graphCommand.Flags().BoolVar(
+		&graphOrphans, "orphans", false,
+		"Report orphan notes and clusters of linked notes, instead of exporting",
+	)
+
+	appCommand.AddCommand(graphCommand)
+}
+
+// runGraphCommand exports the graph of notes, or reports its orphans and clusters.
+func runGraphCommand(cmd *cobra.Command, args []string) {
+	determineService()
+
+	loading.Start()
+	nodes, _, err := service.GetAll("", "", models.NoteIgnoreFiles)
+	loading.Stop()
+	warnIfStale()
+
+	if err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+		return
+	}
+
+	graph := services.NewGraph(nodes)
+	if len(graphRoot) > 0 {
+		if graph, err = graph.SubGraph(graphRoot, graphDepth); err != nil {
+			pkg.Alert(pkg.ErrorL, err.Error())
+			return
+		}
+	}
+
+	if graphOrphans {
+		pkg.PrintGraphReport(graph.Orphans(), graph.Clusters())
+		return
+	}
+
+	out, err := graph.Export(graphFormat)
+	if err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+		return
+	}
+
+	fmt.Println(out)
+}
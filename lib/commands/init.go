@@ -38,6 +38,6 @@ func runInitCommand(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	pkg.Alert(pkg.SuccessL, `Application initialized successfully`)
+	succeed(`Application initialized successfully`)
 	pkg.Print(" > [nt -h/help] for help", color.FgBlue)
 }
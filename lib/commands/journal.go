@@ -0,0 +1,138 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/lib/services"
+	"github.com/insolite-dev/nt/pkg"
+	"github.com/spf13/cobra"
+)
+
+// journalCommand is a command model that used to open daily journal notes.
+var journalCommand = &cobra.Command{
+	Use:   "journal [date]",
+	Short: "Open (or create) the daily note of date, like: 2026-10-18, yesterday or -3",
+	Run:   runJournalCommand,
+}
+
+// journalListCommand is a command model that used to list daily journal notes.
+var journalListCommand = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List daily notes, newest first",
+	Run:     runJournalListCommand,
+}
+
+// todayCommand is a command model that used to open today's daily note.
+var todayCommand = &cobra.Command{
+	Use:   "today",
+	Short: "Open (or create) today's daily note",
+	Run: func(cmd *cobra.Command, args []string) {
+		runJournalCommand(cmd, []string{"today"})
+	},
+}
+
+// yesterdayCommand is a command model that used to open yesterday's daily note.
+var yesterdayCommand = &cobra.Command{
+	Use:   "yesterday",
+	Short: "Open (or create) yesterday's daily note",
+	Run: func(cmd *cobra.Command, args []string) {
+		runJournalCommand(cmd, []string{"yesterday"})
+	},
+}
+
+// journalWeek is the value of week flag, which decides
+// to list only the daily notes of last seven days.
+var journalWeek bool
+
+// initJournalCommand adds [journalCommand], [todayCommand] and [yesterdayCommand] to the [appCommand].
+func initJournalCommand() {
+	journalListCommand.Flags().BoolVarP(
+		&journalWeek, "week", "w", false,
+		"List only the daily notes of last seven days",
+	)
+
+	journalCommand.AddCommand(journalListCommand)
+
+	appCommand.AddCommand(journalCommand)
+	appCommand.AddCommand(todayCommand)
+	appCommand.AddCommand(yesterdayCommand)
+}
+
+// runJournalCommand opens the daily note of provided date, and creates it if it doesn't exist.
+func runJournalCommand(cmd *cobra.Command, args []string) {
+	determineService()
+
+	value := ""
+	if len(args) > 0 {
+		value = args[0]
+	}
+
+	now := time.Now()
+	date, err := services.ParseJournalDate(value, now)
+	if err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+		return
+	}
+
+	settings := service.StateConfig()
+	title := settings.JournalTitle(date)
+
+	if exists, _ := service.IsNodeExists(models.Node{Title: title}); !exists {
+		// Fill the time variables of template by current time, at the day of note.
+		at := date.Add(now.Sub(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())))
+
+		body := fmt.Sprintf("# %v\n", date.Format("2006-01-02"))
+		if len(settings.JournalTemplate) > 0 {
+			body = instantiateTemplate(settings.JournalTemplate, title, at)
+		}
+
+		loading.Start()
+		_, err := services.CreateNote(service, models.Note{Title: title, Body: body})
+		loading.Stop()
+
+		if err != nil {
+			pkg.Alert(pkg.ErrorL, err.Error())
+			return
+		}
+	}
+
+	editAndFinish(models.Node{Title: title})
+}
+
+// runJournalListCommand logs daily notes, newest first.
+func runJournalListCommand(cmd *cobra.Command, args []string) {
+	determineService()
+
+	loading.Start()
+	nodes, _, err := service.GetAll("", "file", models.NotyaIgnoreFiles)
+	loading.Stop()
+	warnIfStale()
+
+	if err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+		return
+	}
+
+	var since time.Time
+	if journalWeek {
+		today, _ := services.ParseJournalDate("today", time.Now())
+		since = today.AddDate(0, 0, -6)
+	}
+
+	notes := services.DailyNotes(nodes, service.StateConfig(), since)
+	if len(notes) == 0 {
+		pkg.Alert(pkg.InfoL, "There are no daily notes")
+		return
+	}
+
+	pkg.PrintDailyNotes(notes)
+}
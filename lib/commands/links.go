@@ -0,0 +1,128 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/insolite-dev/nt/assets"
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/lib/services"
+	"github.com/insolite-dev/nt/pkg"
+	"github.com/spf13/cobra"
+)
+
+// linksCommand is a command model that used to list outgoing links of notes.
+var linksCommand = &cobra.Command{
+	Use:   "links [note]",
+	Short: "List outgoing links of note ([[wiki links]] and relative markdown links)",
+	Run:   runLinksCommand,
+}
+
+// backlinksCommand is a command model that used to list incoming links of note.
+var backlinksCommand = &cobra.Command{
+	Use:   "backlinks <note>",
+	Short: "List incoming links of note, from other notes",
+	Run:   runBacklinksCommand,
+}
+
+// linksDangling is the value of dangling flag, which decides
+// to list only the links that don't refer to an existing note.
+var linksDangling bool
+
+// initLinksCommand adds [linksCommand] and [backlinksCommand] to the [appCommand].
+func initLinksCommand() {
+	linksCommand.Flags().BoolVarP(
+		&linksDangling, "dangling", "d", false,
+		"List only dangling links (of all notes, if note isn't provided)",
+	)
+
+	appCommand.AddCommand(linksCommand)
+	appCommand.AddCommand(backlinksCommand)
+}
+
+// runLinksCommand logs outgoing links of note, or dangling links of all notes.
+func runLinksCommand(cmd *cobra.Command, args []string) {
+	if len(args) == 0 && !linksDangling {
+		pkg.Alert(pkg.ErrorL, "Provide a note to list its links, or use --dangling flag to list dangling links of all notes")
+		return
+	}
+
+	determineService()
+
+	nodes, index := linkIndex()
+
+	var links []models.Link
+	if len(args) == 0 {
+		links = index.DanglingLinks(nodes)
+	} else {
+		for _, link := range index.Links(findNote(nodes, args[0])) {
+			if !linksDangling || link.IsDangling() {
+				links = append(links, link)
+			}
+		}
+	}
+
+	if len(links) == 0 {
+		pkg.Alert(pkg.InfoL, "There are no links")
+		return
+	}
+
+	pkg.PrintLinks(links, false)
+
+	// Dangling links are considered as a problem, like doctor does.
+	if linksDangling {
+		pkg.Alert(pkg.InfoL, fmt.Sprintf("Found %v dangling links", len(links)))
+	}
+}
+
+// runBacklinksCommand logs incoming links of note.
+func runBacklinksCommand(cmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		pkg.Alert(pkg.ErrorL, "Provide a note to list its backlinks")
+		return
+	}
+
+	determineService()
+
+	nodes, index := linkIndex()
+
+	note := findNote(nodes, args[0])
+	links := index.Backlinks(nodes, note.Title)
+	if len(links) == 0 {
+		pkg.Alert(pkg.InfoL, fmt.Sprintf("There are no links to %v", note.Title))
+		return
+	}
+
+	pkg.PrintLinks(links, true)
+}
+
+// linkIndex fetches all notes of current service, and generates their link index.
+func linkIndex() ([]models.Node, *services.LinkIndex) {
+	loading.Start()
+	nodes, _, err := service.GetAll("", "file", models.NotyaIgnoreFiles)
+	loading.Stop()
+	warnIfStale()
+
+	if err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+	}
+
+	return nodes, services.NewLinkIndex(nodes)
+}
+
+// findNote looks up the note of given [title] from [nodes].
+func findNote(nodes []models.Node, title string) models.Node {
+	for _, node := range nodes {
+		if services.HashKey(node.Title) == services.HashKey(title) {
+			return node
+		}
+	}
+
+	pkg.Alert(pkg.ErrorL, assets.NotExists(title, "File").Error())
+	return models.Node{}
+}
@@ -7,7 +7,9 @@
 package commands
 
 import (
+	"github.com/insolite-dev/nt/assets"
 	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/lib/services"
 	"github.com/insolite-dev/nt/pkg"
 	"github.com/spf13/cobra"
 )
@@ -20,8 +22,28 @@ var listCommand = &cobra.Command{
 	Run:     runListCommand,
 }
 
+// listLong is the value of long flag, which decides
+// to print metadata(size, modification time, ...) of nodes.
+var listLong bool
+
+// listTags is the value of tag flag, which limits the list to tagged notes.
+var listTags []string
+
+// listWhere is the value of where flag, which filters the list by a query.
+var listWhere string
+
 // initListCommand adds listCommand to main application command.
 func initListCommand() {
+	listCommand.Flags().BoolVarP(
+		&listLong, "long", "l", false,
+		"List nodes with their metadata (size, modification time and author)",
+	)
+	addTagFlag(listCommand, &listTags, "List only the notes that have this tag (could be repeated)")
+	listCommand.Flags().StringVarP(
+		&listWhere, "where", "w", "",
+		`Filter nodes by a query, like: "tag:oncall AND modified>2026-09-01 AND NOT title:draft" or "@saved-query"`,
+	)
+
 	appCommand.AddCommand(listCommand)
 }
 
@@ -40,10 +62,35 @@ func runListCommand(cmd *cobra.Command, args []string) {
 	nodes, _, err := service.GetAll(additional, "", models.NotyaIgnoreFiles)
 
 	loading.Stop()
+	warnIfStale()
 	if err != nil {
 		pkg.Alert(pkg.ErrorL, err.Error())
 		return
 	}
 
+	nodes = services.SelectTagged(nodes, listTags)
+	if len(nodes) == 0 && len(listTags) > 0 {
+		pkg.Alert(pkg.InfoL, assets.NoTaggedNotes.Error())
+		return
+	}
+
+	if len(listWhere) > 0 {
+		if nodes = selectQueried(nodes, listWhere); len(nodes) == 0 {
+			pkg.Alert(pkg.InfoL, "There are no nodes matching the query")
+			return
+		}
+	}
+
+	if listLong {
+		pkg.PrintNodesLong(nodes)
+		return
+	}
+
+	// Queried nodes don't form a tree, so they're listed by their full titles.
+	if len(listWhere) > 0 {
+		pkg.PrintNodeTitles(nodes)
+		return
+	}
+
 	pkg.PrintNodes(nodes)
 }
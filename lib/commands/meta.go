@@ -0,0 +1,150 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/pkg"
+	"github.com/spf13/cobra"
+)
+
+// metaCommand is a command model that used to manage front matter of notes.
+var metaCommand = &cobra.Command{
+	Use:     "meta",
+	Aliases: []string{"frontmatter"},
+	Short:   "Manage YAML front matter of notes, without opening an editor",
+}
+
+// metaGetCommand is a command model that used to view front matter of note.
+var metaGetCommand = &cobra.Command{
+	Use:   "get <note> [key]",
+	Short: "View front matter of note, or value of its key",
+	Run:   runMetaGetCommand,
+}
+
+// metaSetCommand is a command model that used to set a front matter value of note.
+var metaSetCommand = &cobra.Command{
+	Use:   "set <note> <key> <value>",
+	Short: "Set a front matter value of note (lists could be provided like: [a, b])",
+	Run:   runMetaSetCommand,
+}
+
+// metaUnsetCommand is a command model that used to remove a front matter key of note.
+var metaUnsetCommand = &cobra.Command{
+	Use:   "unset <note> <key>",
+	Short: "Remove a key from front matter of note",
+	Run:   runMetaUnsetCommand,
+}
+
+// initMetaCommand adds [metaCommand] to the [appCommand].
+func initMetaCommand() {
+	metaCommand.AddCommand(metaGetCommand)
+	metaCommand.AddCommand(metaSetCommand)
+	metaCommand.AddCommand(metaUnsetCommand)
+
+	appCommand.AddCommand(metaCommand)
+}
+
+// runMetaGetCommand logs the whole front matter of note, or value of provided key.
+func runMetaGetCommand(cmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		pkg.Alert(pkg.ErrorL, "Provide a note to view its front matter")
+		return
+	}
+
+	determineService()
+
+	_, meta := readMeta(args[0])
+
+	if len(args) == 1 {
+		fmt.Print(meta.ToString())
+		return
+	}
+
+	value, exists := meta.Get(args[1])
+	if !exists {
+		pkg.Alert(pkg.InfoL, fmt.Sprintf("%v isn't set at front matter of %v", args[1], args[0]))
+		return
+	}
+
+	fmt.Println(value)
+}
+
+// runMetaSetCommand updates the value of key at front matter of note.
+func runMetaSetCommand(cmd *cobra.Command, args []string) {
+	if len(args) < 3 {
+		pkg.Alert(pkg.ErrorL, "Provide a note, a key and a value to set")
+		return
+	}
+
+	determineService()
+
+	note, meta := readMeta(args[0])
+	if err := meta.Set(args[1], strings.Join(args[2:], " ")); err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+		return
+	}
+
+	writeMeta(note, meta)
+	succeed(fmt.Sprintf("Set %v of %v", args[1], note.Title))
+}
+
+// runMetaUnsetCommand removes the key from front matter of note.
+func runMetaUnsetCommand(cmd *cobra.Command, args []string) {
+	if len(args) < 2 {
+		pkg.Alert(pkg.ErrorL, "Provide a note and a key to unset")
+		return
+	}
+
+	determineService()
+
+	note, meta := readMeta(args[0])
+	if !meta.Unset(args[1]) {
+		pkg.Alert(pkg.InfoL, fmt.Sprintf("%v isn't set at front matter of %v", args[1], args[0]))
+		return
+	}
+
+	writeMeta(note, meta)
+	succeed(fmt.Sprintf("Unset %v of %v", args[1], note.Title))
+}
+
+// readMeta reads the note of given title and parses its front matter.
+func readMeta(title string) (*models.Note, *models.FrontMatter) {
+	loading.Start()
+	note, err := service.View(models.Note{Title: title})
+	loading.Stop()
+	warnIfStale()
+
+	if err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+	}
+
+	meta := note.Meta
+	if meta == nil {
+		if meta, err = models.ParseFrontMatter(note.Body); err != nil {
+			pkg.Alert(pkg.ErrorL, err.Error())
+		}
+	}
+
+	return note, meta
+}
+
+// writeMeta applies given front matter to the body of note and saves it.
+func writeMeta(note *models.Note, meta *models.FrontMatter) {
+	note.Body = meta.Apply(note.Body)
+
+	loading.Start()
+	_, err := service.Edit(*note)
+	loading.Stop()
+
+	if err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+	}
+}
@@ -19,16 +19,22 @@ import (
 )
 
 var migrateCommand = &cobra.Command{
-	Use:   "migrate",
+	Use:   "migrate [path or pattern...]",
 	Short: "Overwrites [Y] service's data with [X] service (in case of [X] service being current running service)",
 	Run:   runMigrateCommand,
 }
 
+// migrateTags is the value of tag flag, which limits the command to tagged notes.
+var migrateTags []string
+
 func initMigrateCommand() {
+	addTagFlag(migrateCommand, &migrateTags, "Migrate only the notes that have this tag (could be repeated)")
+
 	appCommand.AddCommand(migrateCommand)
 }
 
 func runMigrateCommand(cmd *cobra.Command, args []string) {
+	checkPatterns(args)
 	determineService()
 	loading.Start()
 
@@ -57,9 +63,12 @@ func runMigrateCommand(cmd *cobra.Command, args []string) {
 	}
 
 	selectedService := serviceFromType(selected, true)
+	args = taggedPatterns(service, args, migrateTags)
+
+	resetRetried()
 
 	loading.Start()
-	migratedNodes, errs := service.Migrate(selectedService)
+	migratedNodes, errs := service.Migrate(selectedService, args)
 	loading.Stop()
 
 	if len(migratedNodes) == 0 && len(errs) == 0 {
@@ -67,6 +76,7 @@ func runMigrateCommand(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	printRetried()
 	pkg.PrintErrors("migrate", errs)
-	pkg.Alert(pkg.SuccessL, fmt.Sprintf("Migrated %v nodes", len(migratedNodes)))
+	succeed(fmt.Sprintf("Migrated %v nodes", len(migratedNodes)))
 }
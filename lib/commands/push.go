@@ -19,16 +19,22 @@ import (
 )
 
 var pushCommand = &cobra.Command{
-	Use:   "push",
+	Use:   "push [path or pattern...]",
 	Short: "Pushes all nodes from [X] service to [Y] service(in case, if nodes doesn't exists in [Y] service)",
 	Run:   runPushCommand,
 }
 
+// pushTags is the value of tag flag, which limits the command to tagged notes.
+var pushTags []string
+
 func initPushCommand() {
+	addTagFlag(pushCommand, &pushTags, "Push only the notes that have this tag (could be repeated)")
+
 	appCommand.AddCommand(pushCommand)
 }
 
 func runPushCommand(cmd *cobra.Command, args []string) {
+	checkPatterns(args)
 	determineService()
 	loading.Start()
 
@@ -57,9 +63,12 @@ func runPushCommand(cmd *cobra.Command, args []string) {
 	}
 
 	selectedService := serviceFromType(selected, true)
+	args = taggedPatterns(service, args, pushTags)
+
+	resetRetried()
 
 	loading.Start()
-	pushedNodes, errs := service.Push(selectedService)
+	pushedNodes, errs := service.Push(selectedService, args)
 	loading.Stop()
 
 	if len(pushedNodes) == 0 && len(errs) == 0 {
@@ -67,6 +76,7 @@ func runPushCommand(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	printRetried()
 	pkg.PrintErrors("push", errs)
-	pkg.Alert(pkg.SuccessL, fmt.Sprintf("Pushed %v nodes", len(pushedNodes)))
+	succeed(fmt.Sprintf("Pushed %v nodes", len(pushedNodes)))
 }
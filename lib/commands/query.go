@@ -0,0 +1,193 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/insolite-dev/nt/assets"
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/lib/services"
+	"github.com/insolite-dev/nt/pkg"
+	"github.com/spf13/cobra"
+)
+
+// queryNameRegex matches the valid names of saved queries.
+var queryNameRegex = regexp.MustCompile(`^[\w-]+$`)
+
+// queryCommand is a command model that used to manage saved queries.
+var queryCommand = &cobra.Command{
+	Use:     "query",
+	Aliases: []string{"queries"},
+	Short:   "List saved queries of nodes",
+	Run:     runQueryCommand,
+}
+
+// querySaveCommand is a command model that used to save a query by name.
+var querySaveCommand = &cobra.Command{
+	Use:   "save <name> <query>",
+	Short: "Save a query by name, to run it later or refer it from other queries as @name",
+	Run:   runQuerySaveCommand,
+}
+
+// queryRemoveCommand is a command model that used to remove a saved query.
+var queryRemoveCommand = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a saved query",
+	Run:     runQueryRemoveCommand,
+}
+
+// queryRunCommand is a command model that used to list the nodes matched by a query.
+var queryRunCommand = &cobra.Command{
+	Use:   "run <name or query>",
+	Short: "List nodes matched by a saved query, or by a provided query",
+	Run:   runQueryRunCommand,
+}
+
+// initQueryCommand adds [queryCommand] to the [appCommand].
+func initQueryCommand() {
+	queryCommand.AddCommand(querySaveCommand)
+	queryCommand.AddCommand(queryRemoveCommand)
+	queryCommand.AddCommand(queryRunCommand)
+
+	appCommand.AddCommand(queryCommand)
+}
+
+// runQueryCommand logs all saved queries of current service.
+func runQueryCommand(cmd *cobra.Command, args []string) {
+	determineService()
+
+	queries := service.StateConfig().Queries
+	if len(queries) == 0 {
+		pkg.Alert(pkg.InfoL, "There are no saved queries")
+		return
+	}
+
+	pkg.PrintQueries(queries)
+}
+
+// runQuerySaveCommand validates and saves given query by name to settings.
+func runQuerySaveCommand(cmd *cobra.Command, args []string) {
+	if len(args) < 2 {
+		pkg.Alert(pkg.ErrorL, "Provide a name and a query to save")
+		return
+	}
+
+	name, query := strings.TrimPrefix(args[0], "@"), strings.Join(args[1:], " ")
+	if !queryNameRegex.MatchString(name) {
+		pkg.Alert(pkg.ErrorL, assets.InvalidQueryName.Error())
+		return
+	}
+
+	determineService()
+
+	settings := service.StateConfig()
+
+	queries := map[string]string{}
+	for k, v := range settings.Queries {
+		queries[k] = v
+	}
+	queries[name] = query
+
+	// Parse via reference, to validate recursive references too.
+	if _, err := services.ParseQuery("@"+name, queries); err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+		return
+	}
+
+	settings.Queries = queries
+	writeQueries(settings)
+
+	succeed(fmt.Sprintf("Saved query @%v", name))
+}
+
+// runQueryRemoveCommand removes the saved query of given name from settings.
+func runQueryRemoveCommand(cmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		pkg.Alert(pkg.ErrorL, "Provide a name of saved query to remove")
+		return
+	}
+
+	determineService()
+
+	name := strings.TrimPrefix(args[0], "@")
+
+	settings := service.StateConfig()
+	if _, exists := settings.Queries[name]; !exists {
+		pkg.Alert(pkg.ErrorL, assets.NotExists("", "Saved query").Error())
+		return
+	}
+
+	queries := map[string]string{}
+	for k, v := range settings.Queries {
+		if k != name {
+			queries[k] = v
+		}
+	}
+
+	settings.Queries = queries
+	writeQueries(settings)
+
+	succeed(fmt.Sprintf("Removed query @%v", name))
+}
+
+// runQueryRunCommand logs the nodes, matched by a saved query or by provided query.
+func runQueryRunCommand(cmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		pkg.Alert(pkg.ErrorL, "Provide a name of saved query, or a query to run")
+		return
+	}
+
+	determineService()
+
+	query := strings.Join(args, " ")
+	if _, saved := service.StateConfig().Queries[strings.TrimPrefix(query, "@")]; saved {
+		query = "@" + strings.TrimPrefix(query, "@")
+	}
+
+	loading.Start()
+	nodes, _, err := service.GetAll("", "", models.NoteIgnoreFiles)
+	loading.Stop()
+	warnIfStale()
+
+	if err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+		return
+	}
+
+	if nodes = selectQueried(nodes, query); len(nodes) == 0 {
+		pkg.Alert(pkg.InfoL, "There are no nodes matching the query")
+		return
+	}
+
+	pkg.PrintNodeTitles(nodes)
+}
+
+// selectQueried filters [nodes] by given [query], which could refer to
+// the saved queries of current service. See [services.ParseQuery].
+func selectQueried(nodes []models.Node, query string) []models.Node {
+	q, err := services.ParseQuery(query, service.StateConfig().Queries)
+	if err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+	}
+
+	return q.Select(nodes)
+}
+
+// writeQueries overwrites the settings of current service, with updated queries.
+func writeQueries(settings models.Settings) {
+	loading.Start()
+	err := service.WriteSettings(settings)
+	loading.Stop()
+
+	if err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+	}
+}
@@ -0,0 +1,160 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/insolite-dev/nt/assets"
+	"github.com/insolite-dev/nt/lib/services"
+	"github.com/insolite-dev/nt/pkg"
+	"github.com/spf13/cobra"
+)
+
+// queueCommand is a command model that used to manage
+// operations, queued while remote was unreachable.
+var queueCommand = &cobra.Command{
+	Use:   "queue",
+	Short: "Manage operations queued while remote was unreachable",
+	Run:   runQueueListCommand,
+}
+
+// queueListCommand is a command model that used to list queued operations.
+var queueListCommand = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List queued operations",
+	Run:     runQueueListCommand,
+}
+
+// queueRetryCommand is a command model that used to replay queued operations.
+var queueRetryCommand = &cobra.Command{
+	Use:   "retry",
+	Short: "Replay queued operations",
+	Run:   runQueueRetryCommand,
+}
+
+// queueDropCommand is a command model that used to remove a queued operation.
+var queueDropCommand = &cobra.Command{
+	Use:     "drop",
+	Aliases: []string{"rm"},
+	Short:   "Drop a queued operation without applying it",
+	Run:     runQueueDropCommand,
+}
+
+// queueForce is the value of force flag, which decides to replay
+// conflicted operations too, without checking remote changes.
+var queueForce bool
+
+// initQueueCommand adds [queueCommand] to the [appCommand].
+func initQueueCommand() {
+	queueRetryCommand.Flags().BoolVar(
+		&queueForce, "force", false,
+		"Replay conflicted operations too, by overwriting remote changes",
+	)
+
+	queueCommand.AddCommand(queueListCommand)
+	queueCommand.AddCommand(queueRetryCommand)
+	queueCommand.AddCommand(queueDropCommand)
+
+	appCommand.AddCommand(queueCommand)
+}
+
+// queuedFirebase returns the firebase service, to manage its queue.
+func queuedFirebase() *services.FirebaseService {
+	fire, ok := serviceFromType(services.FIRE.ToStr(), true).(*services.FirebaseService)
+	if !ok {
+		pkg.Alert(pkg.ErrorL, assets.NotAvailableForFirebase.Error())
+		os.Exit(1)
+	}
+
+	return fire
+}
+
+// replayQueue replays the queued operations of [fire],
+// or informs about queueing, if it's offline.
+func replayQueue(fire *services.FirebaseService) {
+	if fire.Offline {
+		pkg.Print("\n Firebase is unreachable, changes will be queued until the next connection", color.FgYellow)
+		return
+	}
+
+	if len(fire.Queue()) == 0 {
+		return
+	}
+
+	loading.Start()
+	replayed, errs := fire.Replay(false)
+	loading.Stop()
+
+	if len(replayed) > 0 {
+		pkg.Print(fmt.Sprintf("\n Replayed %v queued operations", len(replayed)), color.FgHiGreen)
+	}
+
+	pkg.PrintErrors("replay", errs)
+}
+
+// runQueueListCommand lists queued operations of firebase service.
+func runQueueListCommand(cmd *cobra.Command, args []string) {
+	queue := queuedFirebase().Queue()
+	if len(queue) == 0 {
+		pkg.Print("No queued operations", color.FgHiGreen)
+		return
+	}
+
+	pkg.PrintOperations(queue)
+}
+
+// runQueueRetryCommand replays queued operations of firebase service.
+func runQueueRetryCommand(cmd *cobra.Command, args []string) {
+	fire := queuedFirebase()
+	if fire.Offline {
+		pkg.Alert(pkg.ErrorL, "Firebase is unreachable, cannot replay queued operations")
+		return
+	}
+
+	loading.Start()
+	replayed, errs := fire.Replay(queueForce)
+	loading.Stop()
+
+	pkg.PrintErrors("replay", errs)
+	succeed(fmt.Sprintf("Replayed %v queued operations", len(replayed)))
+}
+
+// runQueueDropCommand removes provided(or selected) operation from queue.
+func runQueueDropCommand(cmd *cobra.Command, args []string) {
+	fire := queuedFirebase()
+
+	var selected string
+	if len(args) > 0 {
+		selected = args[0]
+	} else {
+		options := []string{}
+		for _, op := range fire.Queue() {
+			options = append(options, fmt.Sprintf("%v %v %v", op.ID, op.Kind, op.Title))
+		}
+
+		survey.AskOne(assets.ChooseNodePrompt("operation", "drop", options), &selected)
+	}
+
+	if len(selected) == 0 {
+		os.Exit(-1)
+		return
+	}
+
+	id := strings.Split(selected, " ")[0]
+	if err := fire.Drop(id); err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+		return
+	}
+
+	succeed(fmt.Sprintf("Dropped operation %v", id))
+}
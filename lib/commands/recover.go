@@ -0,0 +1,139 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/insolite-dev/nt/assets"
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/lib/services"
+	"github.com/insolite-dev/nt/pkg"
+	"github.com/spf13/cobra"
+)
+
+// recoverCommand is a command model which used to recover
+// unfinished edits of remote notes.
+var recoverCommand = &cobra.Command{
+	Use:     "recover",
+	Aliases: []string{"rc"},
+	Short:   "Recover unfinished edits of remote notes",
+	Run:     runRecoverCommand,
+}
+
+// initRecoverCommand adds recoverCommand to main application command.
+func initRecoverCommand() {
+	appCommand.AddCommand(recoverCommand)
+}
+
+// runRecoverCommand lists unfinished edits, and applies selected action to selected edit.
+func runRecoverCommand(cmd *cobra.Command, args []string) {
+	fire, ok := serviceFromType(services.FIRE.ToStr(), true).(*services.FirebaseService)
+	if !ok {
+		pkg.Alert(pkg.ErrorL, assets.NotAvailableForFirebase.Error())
+		return
+	}
+
+	entries := fire.Unfinished()
+	if len(entries) == 0 {
+		pkg.Print("No unfinished edits", color.FgHiGreen)
+		return
+	}
+
+	titles := []string{}
+	for _, e := range entries {
+		titles = append(titles, e.Title)
+	}
+
+	// Take note title from arguments. If it's provided.
+	var selected string
+	if len(args) > 0 {
+		selected = args[0]
+	} else {
+		survey.AskOne(assets.ChooseNodePrompt("note", "recover", titles), &selected)
+	}
+
+	var entry *models.JournalEntry
+	for i, e := range entries {
+		if services.HashKey(e.Title) == services.HashKey(selected) {
+			entry = &entries[i]
+		}
+	}
+
+	if entry == nil {
+		pkg.Alert(pkg.ErrorL, assets.NotExists("", "Unfinished edit of "+selected).Error())
+		return
+	}
+
+	var action string
+	survey.AskOne(assets.RecoverActionPrompt, &action)
+
+	var err error
+	switch action {
+	case "push":
+		loading.Start()
+		err = fire.Recover(*entry)
+		loading.Stop()
+	case "edit":
+		err = fire.Resume(*entry)
+	case "discard":
+		err = fire.Discard(*entry)
+	default:
+		os.Exit(-1)
+		return
+	}
+
+	if err == assets.EditConflict {
+		err = resolveConflict(fire, *entry)
+	}
+
+	if err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+		return
+	}
+
+	succeed(fmt.Sprintf("Unfinished edit of %v is resolved", entry.Title))
+}
+
+// resolveConflict asks for a resolution of edit conflict of [entry], and applies it.
+// Diff of remote and cached bodies could be viewed before choosing the resolution.
+func resolveConflict(fire *services.FirebaseService, entry models.JournalEntry) error {
+	for {
+		var resolution string
+		survey.AskOne(assets.ConflictResolutionPrompt, &resolution)
+
+		switch resolution {
+		case "":
+			os.Exit(-1)
+			return nil
+		case "diff":
+			loading.Start()
+			theirs, err := fire.Theirs(entry)
+			loading.Stop()
+			if err != nil {
+				return err
+			}
+
+			mine, err := fire.Mine(entry)
+			if err != nil {
+				return err
+			}
+
+			pkg.PrintDiff(pkg.Diff(theirs.Body, mine))
+			continue
+		}
+
+		loading.Start()
+		err := fire.Resolve(entry, resolution)
+		loading.Stop()
+
+		return err
+	}
+}
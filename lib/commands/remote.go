@@ -42,10 +42,19 @@ var disconnectFromRemoteCommand = &cobra.Command{
 	Run:   runRemoteDisconnectCommand,
 }
 
+// remoteLayoutCommand is a command model that used
+// to migrate firebase collection to another document layout.
+var remoteLayoutCommand = &cobra.Command{
+	Use:   "layout",
+	Short: "Migrate firebase collection to nested or flat document layout",
+	Run:   runRemoteLayoutCommand,
+}
+
 // initRemoteCommand adds [remoteCommand] to the [appCommand].
 func initRemoteCommand() {
 	remoteCommand.AddCommand(connectToRemoteCommand)
 	remoteCommand.AddCommand(disconnectFromRemoteCommand)
+	remoteCommand.AddCommand(remoteLayoutCommand)
 
 	appCommand.AddCommand(remoteCommand)
 }
@@ -105,7 +114,7 @@ func runRemoteConnectCommand(cmd *cobra.Command, args []string) {
 		updatedS := s.CopyWith(nil, nil, nil, nil, &promptResult.FirebaseProjectID, &promptResult.FirebaseAccountKey, &promptResult.FirebaseCollection)
 
 		// Validate provided firebase connection:
-		isEnabled := services.IsFirebaseEnabled(updatedS, &localService)
+		isEnabled := services.IsFirebaseEnabled(updatedS)
 
 		loading.Stop()
 
@@ -119,7 +128,7 @@ func runRemoteConnectCommand(cmd *cobra.Command, args []string) {
 		loading.Stop()
 	}
 
-	pkg.Alert(pkg.SuccessL, fmt.Sprintf("Successfully connected to the specified %s project.", selected))
+	succeed(fmt.Sprintf("Successfully connected to the specified %s project.", selected))
 }
 
 // runRemoteDisconnectCommand removes connection from concrete remove service
@@ -156,7 +165,47 @@ func runRemoteDisconnectCommand(cmd *cobra.Command, args []string) {
 
 	loading.Stop()
 
-	pkg.Alert(pkg.SuccessL, fmt.Sprintf("Successfully disconnected from specified %s service", selected))
+	succeed(fmt.Sprintf("Successfully disconnected from specified %s service", selected))
+}
+
+// runRemoteLayoutCommand migrates firebase collection to provided(or selected) document layout.
+func runRemoteLayoutCommand(cmd *cobra.Command, args []string) {
+	fire, ok := serviceFromType(services.FIRE.ToStr(), true).(*services.FirebaseService)
+	if !ok {
+		pkg.Alert(pkg.ErrorL, assets.NotAvailableForFirebase.Error())
+		return
+	}
+
+	current := fire.Config.FireLayout()
+
+	var selected string
+	if len(args) > 0 {
+		selected = args[0]
+	} else {
+		survey.AskOne(assets.ChooseLayoutPrompt(current), &selected)
+	}
+
+	if len(selected) == 0 {
+		os.Exit(-1)
+		return
+	}
+
+	if selected == current {
+		pkg.Alert(pkg.InfoL, fmt.Sprintf("Collection is already stored in %s layout", current))
+		return
+	}
+
+	loading.Start()
+	migrated, errs := fire.MigrateLayout(selected)
+	loading.Stop()
+
+	pkg.PrintErrors("migrate", errs)
+	if len(errs) > 0 && len(migrated) == 0 {
+		os.Exit(1)
+		return
+	}
+
+	succeed(fmt.Sprintf("Migrated %v nodes to %s layout", len(migrated), selected))
 }
 
 // Returns a list of all remote services by splitting them by their enabled or disabled level.
@@ -167,7 +216,7 @@ func listAllRemote() ([]string, []string) {
 	for _, s := range services.RemoteServices {
 		switch s {
 		case services.FIRE.ToStr():
-			if services.IsFirebaseEnabled(service.StateConfig(), &localService) {
+			if services.IsFirebaseEnabled(service.StateConfig()) {
 				allEnabled = append(allEnabled, s)
 			} else {
 				allDisabled = append(allDisabled, s)
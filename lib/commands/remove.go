@@ -47,7 +47,7 @@ func runRemoveCommand(cmd *cobra.Command, args []string) {
 		loading.Stop()
 
 		pkg.PrintErrors("remove", errs)
-		pkg.Alert(pkg.SuccessL, fmt.Sprintf("Removed %v nodes", len(clearedNodes)))
+		succeed(fmt.Sprintf("Removed %v nodes", len(clearedNodes)))
 		return
 	}
 
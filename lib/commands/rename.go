@@ -7,6 +7,7 @@
 package commands
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/AlecAivazis/survey/v2"
@@ -24,8 +25,25 @@ var renameCommand = &cobra.Command{
 	Run:     runRenameCommand,
 }
 
+// renameUpdateLinks is the value of update-links flag, which decides
+// to rewrite the links of other notes that refer to the renamed node.
+var renameUpdateLinks bool
+
+// renameDryRun is the value of dry-run flag, which decides to only
+// list the links that would be rewritten, without renaming the node.
+var renameDryRun bool
+
 // initRenameCommand adds renameCommand to main application command.
 func initRenameCommand() {
+	renameCommand.Flags().BoolVarP(
+		&renameUpdateLinks, "update-links", "u", false,
+		"Rewrite wiki links and relative markdown links that refer to the renamed node",
+	)
+	renameCommand.Flags().BoolVarP(
+		&renameDryRun, "dry-run", "n", false,
+		"List the notes which's links would be rewritten, without making any change",
+	)
+
 	appCommand.AddCommand(renameCommand)
 }
 
@@ -94,6 +112,23 @@ func rename(selected string, newname string) {
 		New:     models.Node{Title: newname},
 	}
 
+	// Links must be resolved before renaming, while they still refer to the existing node.
+	var rewrites []models.LinkRewrite
+	if renameUpdateLinks || renameDryRun {
+		nodes, index := linkIndex()
+		rewrites = index.RenameLinks(nodes, editNode)
+	}
+
+	if renameDryRun {
+		if len(rewrites) == 0 {
+			pkg.Alert(pkg.InfoL, fmt.Sprintf("There are no links to %v", selected))
+			return
+		}
+
+		pkg.PrintLinkRewrites(rewrites)
+		return
+	}
+
 	loading.Start()
 	err := service.Rename(editNode)
 	loading.Stop()
@@ -102,4 +137,55 @@ func rename(selected string, newname string) {
 		pkg.Alert(pkg.ErrorL, err.Error())
 		return
 	}
+
+	updateLinks(rewrites)
+}
+
+// updateLinks saves the rewritten bodies of notes, and logs the rewritten links.
+// Notes changed after their links were resolved aren't overwritten.
+func updateLinks(rewrites []models.LinkRewrite) {
+	if len(rewrites) == 0 {
+		return
+	}
+
+	loading.Start()
+	updated := []models.LinkRewrite{}
+	errs := []error{}
+	for _, rewrite := range rewrites {
+		if err := updateLink(rewrite); err != nil {
+			errs = append(errs, assets.CannotDoSth("rewrite links of", rewrite.Title, err))
+			continue
+		}
+
+		updated = append(updated, rewrite)
+	}
+	loading.Stop()
+
+	pkg.PrintLinkRewrites(updated)
+	pkg.PrintErrors("rewrite", errs)
+	if len(errs) > 0 {
+		os.Exit(1)
+	}
+}
+
+// updateLink saves the rewritten body of a note, if note wasn't changed after
+// its links were resolved. Notes moved by renaming are re-created, so their
+// modification time is taken after renaming and their bodies are compared.
+func updateLink(rewrite models.LinkRewrite) error {
+	note := models.Note{Title: rewrite.Title, Body: rewrite.Body, UpdatedAt: rewrite.UpdatedAt}
+	if note.UpdatedAt.IsZero() {
+		current, err := service.View(models.Note{Title: rewrite.Title})
+		if err != nil {
+			return err
+		}
+
+		if pkg.Hash(current.Body) != rewrite.Hash {
+			return assets.EditConflict
+		}
+
+		note.UpdatedAt = current.UpdatedAt
+	}
+
+	_, err := service.Edit(note)
+	return err
 }
@@ -0,0 +1,80 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/lib/services"
+	"github.com/insolite-dev/nt/pkg"
+	"github.com/spf13/cobra"
+)
+
+// statusCommand is a command model that used to compare local service with a remote.
+var statusCommand = &cobra.Command{
+	Use:     "status",
+	Aliases: []string{"st"},
+	Short:   "Compare local notes with remote, exits with non-zero code if they're out of sync",
+	Run:     runStatusCommand,
+}
+
+// Values of status command flags.
+var (
+	statusRemote string
+	statusAll    bool
+)
+
+// initStatusCommand adds [statusCommand] to the [appCommand].
+func initStatusCommand() {
+	statusCommand.Flags().StringVarP(
+		&statusRemote, "remote", "r", services.FIRE.ToStr(),
+		"Remote service to compare with",
+	)
+	statusCommand.Flags().BoolVarP(
+		&statusAll, "all", "a", false,
+		"List identical nodes too",
+	)
+
+	appCommand.AddCommand(statusCommand)
+}
+
+// runStatusCommand compares local service with selected remote, and logs the differences.
+func runStatusCommand(cmd *cobra.Command, args []string) {
+	remote := serviceFromType(statusRemote, true)
+	if remote == nil || remote.Type() == localService.Type() {
+		pkg.Alert(pkg.ErrorL, fmt.Sprintf("%v isn't a remote service", statusRemote))
+		return
+	}
+
+	loading.Start()
+	report, err := localService.(*services.LocalService).Status(remote)
+	loading.Stop()
+
+	printWarnings()
+
+	if err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+		return
+	}
+
+	pkg.PrintStatus(*report, statusAll)
+
+	if report.InSync() {
+		pkg.Print(fmt.Sprintf("Everything up-to-date with %v", report.Remote), color.FgHiGreen)
+		return
+	}
+
+	pkg.Alert(pkg.InfoL, fmt.Sprintf(
+		"Out of sync with %v: %v only local, %v only remote, %v modified",
+		report.Remote,
+		len(report.Filter(models.LocalOnlyState)),
+		len(report.Filter(models.RemoteOnlyState)),
+		len(report.Filter(models.ModifiedState)),
+	))
+}
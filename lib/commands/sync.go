@@ -0,0 +1,129 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/lib/services"
+	"github.com/insolite-dev/nt/pkg"
+	"github.com/spf13/cobra"
+)
+
+// syncCommand is a command model that used to sync local service with a remote.
+var syncCommand = &cobra.Command{
+	Use:     "sync",
+	Aliases: []string{"daemon"},
+	Short:   "Sync local notes with remote (push local changes, fetch remote changes)",
+	Run:     runSyncCommand,
+}
+
+// syncStatusCommand is a command model that used to view status of sync daemon.
+var syncStatusCommand = &cobra.Command{
+	Use:   "status",
+	Short: "View status of sync daemon",
+	Run:   runSyncStatusCommand,
+}
+
+// Values of sync command flags.
+var (
+	syncWatch    bool
+	syncRemote   string
+	syncDebounce time.Duration
+	syncInterval time.Duration
+	syncTags     []string
+)
+
+// initSyncCommand adds [syncCommand] to the [appCommand].
+func initSyncCommand() {
+	syncCommand.Flags().BoolVarP(
+		&syncWatch, "watch", "w", false,
+		"Keep running, push local changes as they happen and fetch remote changes periodically",
+	)
+	syncCommand.Flags().StringVarP(
+		&syncRemote, "remote", "r", services.FIRE.ToStr(),
+		"Remote service to sync with",
+	)
+	syncCommand.Flags().DurationVar(
+		&syncDebounce, "debounce", services.DefaultSyncDebounce,
+		"Quiet period after the last local change, before pushing",
+	)
+	syncCommand.Flags().DurationVar(
+		&syncInterval, "interval", services.DefaultSyncInterval,
+		"Period of fetching remote changes",
+	)
+
+	addTagFlag(syncCommand, &syncTags, "Sync only the notes that have this tag (could be repeated)")
+
+	syncCommand.AddCommand(syncStatusCommand)
+	appCommand.AddCommand(syncCommand)
+}
+
+// runSyncCommand syncs local service with selected remote once,
+// or keeps syncing them until interrupted, if watch flag is provided.
+func runSyncCommand(cmd *cobra.Command, args []string) {
+	remote := serviceFromType(syncRemote, true)
+	if remote == nil || remote.Type() == localService.Type() {
+		pkg.Alert(pkg.ErrorL, fmt.Sprintf("%v isn't a remote service", syncRemote))
+		return
+	}
+
+	notyaPath, _ := localService.Path()
+	logFile, err := os.OpenFile(notyaPath+models.SyncLogName, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+		return
+	}
+	defer logFile.Close()
+
+	var output io.Writer = logFile
+	if syncWatch {
+		output = io.MultiWriter(logFile, os.Stdout)
+	}
+
+	syncer := services.NewSyncer(localService, remote, log.New(output, "", log.LstdFlags))
+	syncer.Debounce = syncDebounce
+	syncer.Interval = syncInterval
+	syncer.Tags = syncTags
+
+	if !syncWatch {
+		loading.Start()
+		errs := syncer.Sync()
+		loading.Stop()
+
+		pkg.PrintErrors("sync", errs)
+		succeed(fmt.Sprintf("Pushed %v and fetched %v nodes", syncer.Status.Pushed, syncer.Status.Fetched))
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := syncer.Watch(ctx); err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+	}
+}
+
+// runSyncStatusCommand logs the last status of sync daemon.
+func runSyncStatusCommand(cmd *cobra.Command, args []string) {
+	status, err := services.ReadSyncStatus(localService)
+	if err != nil {
+		pkg.Print("Sync daemon has never been run", color.FgHiYellow)
+		return
+	}
+
+	pkg.PrintSyncStatus(*status, status.IsRunning(time.Now()))
+}
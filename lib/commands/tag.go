@@ -0,0 +1,180 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/lib/services"
+	"github.com/insolite-dev/nt/pkg"
+	"github.com/spf13/cobra"
+)
+
+// tagCommand is a command model that used to manage tags of notes.
+var tagCommand = &cobra.Command{
+	Use:   "tag",
+	Short: "Manage tags of notes",
+}
+
+// tagAddCommand is a command model that used to add tags to front matter of note.
+var tagAddCommand = &cobra.Command{
+	Use:   "add <note> <tag...>",
+	Short: "Add tags to note",
+	Run:   runTagAddCommand,
+}
+
+// tagRemoveCommand is a command model that used to remove tags from front matter of note.
+var tagRemoveCommand = &cobra.Command{
+	Use:     "remove <note> <tag...>",
+	Aliases: []string{"rm"},
+	Short:   "Remove tags from note",
+	Run:     runTagRemoveCommand,
+}
+
+// tagListCommand is a command model that used to list tags of note.
+var tagListCommand = &cobra.Command{
+	Use:     "list <note>",
+	Aliases: []string{"ls"},
+	Short:   "List tags of note (from front matter and inline #tags)",
+	Run:     runTagListCommand,
+}
+
+// tagsCommand is a command model that used to list all tags with their note counts.
+var tagsCommand = &cobra.Command{
+	Use:   "tags",
+	Short: "List all tags with their note counts",
+	Run:   runTagsCommand,
+}
+
+// initTagCommand adds [tagCommand] and [tagsCommand] to the [appCommand].
+func initTagCommand() {
+	tagCommand.AddCommand(tagAddCommand)
+	tagCommand.AddCommand(tagRemoveCommand)
+	tagCommand.AddCommand(tagListCommand)
+
+	appCommand.AddCommand(tagCommand)
+	appCommand.AddCommand(tagsCommand)
+}
+
+// runTagAddCommand adds given tags to the front matter of note.
+func runTagAddCommand(cmd *cobra.Command, args []string) {
+	if len(args) < 2 {
+		pkg.Alert(pkg.ErrorL, "Provide a note and tags to add")
+		return
+	}
+
+	determineService()
+
+	note, meta := readMeta(args[0])
+
+	tags := meta.Tags
+	for _, tag := range args[1:] {
+		if tag = models.NormalizeTag(tag); len(tag) > 0 && indexOfTag(tags, tag) < 0 {
+			tags = append(tags, tag)
+		}
+	}
+
+	if len(tags) == len(meta.Tags) {
+		pkg.Alert(pkg.InfoL, fmt.Sprintf("%v is already tagged", note.Title))
+		return
+	}
+
+	_ = meta.SetList(models.TagsKey, tags)
+	writeMeta(note, meta)
+
+	succeed(fmt.Sprintf("Tagged %v as: %v", note.Title, strings.Join(tags, ", ")))
+}
+
+// runTagRemoveCommand removes given tags from the front matter of note.
+// Inline tags are a part of content, so they couldn't be removed.
+func runTagRemoveCommand(cmd *cobra.Command, args []string) {
+	if len(args) < 2 {
+		pkg.Alert(pkg.ErrorL, "Provide a note and tags to remove")
+		return
+	}
+
+	determineService()
+
+	note, meta := readMeta(args[0])
+
+	tags := append([]string{}, meta.Tags...)
+	for _, tag := range args[1:] {
+		if i := indexOfTag(tags, models.NormalizeTag(tag)); i >= 0 {
+			tags = append(tags[:i], tags[i+1:]...)
+		}
+	}
+
+	if len(tags) == len(meta.Tags) {
+		pkg.Alert(pkg.InfoL, fmt.Sprintf("Provided tags aren't at front matter of %v, inline tags could be removed only by editing", note.Title))
+		return
+	}
+
+	_ = meta.SetList(models.TagsKey, tags)
+	writeMeta(note, meta)
+
+	succeed(fmt.Sprintf("Removed tags of %v", note.Title))
+}
+
+// runTagListCommand logs all tags of note.
+func runTagListCommand(cmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		pkg.Alert(pkg.ErrorL, "Provide a note to list its tags")
+		return
+	}
+
+	determineService()
+
+	loading.Start()
+	note, err := service.View(models.Note{Title: args[0]})
+	loading.Stop()
+	warnIfStale()
+
+	if err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+		return
+	}
+
+	for _, tag := range models.ParseTags(note.Body) {
+		fmt.Println("#" + tag)
+	}
+}
+
+// runTagsCommand logs all tags of current service with their note counts.
+func runTagsCommand(cmd *cobra.Command, args []string) {
+	determineService()
+
+	loading.Start()
+	nodes, _, err := service.GetAll("", "file", models.NotyaIgnoreFiles)
+	loading.Stop()
+	warnIfStale()
+
+	if err != nil {
+		pkg.Alert(pkg.ErrorL, err.Error())
+		return
+	}
+
+	counts := services.CountTags(nodes)
+	if len(counts) == 0 {
+		pkg.Alert(pkg.InfoL, "There are no tagged notes")
+		return
+	}
+
+	pkg.PrintTags(counts)
+}
+
+// indexOfTag returns the index of [tag] at [tags], by ignoring case of tags.
+func indexOfTag(tags []string, tag string) int {
+	for i, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/insolite-dev/nt/assets"
 	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/lib/services"
 	"github.com/insolite-dev/nt/pkg"
 	"github.com/spf13/cobra"
 )
@@ -22,11 +23,38 @@ var viewCommand = &cobra.Command{
 	Run:     runViewCommand,
 }
 
+// viewMeta is the value of meta flag, which decides
+// to print metadata(path, hash, ...) of note instead of its body.
+var viewMeta bool
+
+// viewTags is the value of tag flag, which limits the selection to tagged notes.
+var viewTags []string
+
 // initViewCommand adds viewCommand to main application command.
 func initViewCommand() {
+	viewCommand.Flags().BoolVarP(
+		&viewMeta, "meta", "m", false,
+		"View metadata of note (path, content hash, size, timestamps) instead of its body",
+	)
+	addTagFlag(viewCommand, &viewTags, "Select only from the notes that have this tag (could be repeated)")
+
 	appCommand.AddCommand(viewCommand)
 }
 
+// printNote logs given note by the mode provided from flags.
+func printNote(note models.Note) {
+	if note.Meta == nil {
+		note.Meta, _ = models.ParseFrontMatter(note.Body)
+	}
+
+	if viewMeta {
+		pkg.PrintNoteMeta(note, service.Type())
+		return
+	}
+
+	pkg.PrintNote(note, service.Type())
+}
+
 // runViewCommand runs appropriate service commands to log full note data.
 func runViewCommand(cmd *cobra.Command, args []string) {
 	determineService()
@@ -37,11 +65,12 @@ func runViewCommand(cmd *cobra.Command, args []string) {
 	if len(args) > 0 {
 		note, err := service.View(models.Note{Title: args[0]})
 		loading.Stop()
+		warnIfStale()
 
 		if err != nil {
 			pkg.Alert(pkg.ErrorL, err.Error())
 		} else {
-			pkg.PrintNote(*note, service.Type())
+			printNote(*note)
 		}
 
 		return
@@ -50,11 +79,24 @@ func runViewCommand(cmd *cobra.Command, args []string) {
 	// Generate array of all note names.
 	nodes, noteNames, err := service.GetAll("", "file", models.NotyaIgnoreFiles)
 	loading.Stop()
+	warnIfStale()
 	if err != nil {
 		pkg.Alert(pkg.ErrorL, err.Error())
 		return
 	}
 
+	if len(viewTags) > 0 {
+		nodes, noteNames = services.SelectTagged(nodes, viewTags), []string{}
+		for _, n := range nodes {
+			noteNames = append(noteNames, n.Title)
+		}
+
+		if len(nodes) == 0 {
+			pkg.Alert(pkg.InfoL, assets.NoTaggedNotes.Error())
+			return
+		}
+	}
+
 	// Ask for note selection.
 	var selected string
 	survey.AskOne(
@@ -64,7 +106,7 @@ func runViewCommand(cmd *cobra.Command, args []string) {
 
 	for _, n := range nodes {
 		if n.Title == selected {
-			pkg.PrintNote(n.ToNote(), service.Type())
+			printNote(n.ToNote())
 		}
 	}
 }
@@ -0,0 +1,101 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/fatih/color"
+	"github.com/insolite-dev/nt/assets"
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/lib/services"
+	"github.com/insolite-dev/nt/pkg"
+	"github.com/spf13/cobra"
+)
+
+// watchCommand is a command model that used to stream remote changes in realtime.
+var watchCommand = &cobra.Command{
+	Use:   "watch",
+	Short: "Stream changes of remote notes in realtime",
+	Run:   runWatchCommand,
+}
+
+// Values of watch command flags.
+var (
+	watchJSON  bool
+	watchApply bool
+)
+
+// initWatchCommand adds [watchCommand] to the [appCommand].
+func initWatchCommand() {
+	watchCommand.Flags().BoolVar(
+		&watchJSON, "json", false,
+		"Print each change as a JSON line",
+	)
+	watchCommand.Flags().BoolVar(
+		&watchApply, "apply", false,
+		"Apply each change to the local service",
+	)
+
+	appCommand.AddCommand(watchCommand)
+}
+
+// runWatchCommand streams changes of remote collection, until interrupted.
+func runWatchCommand(cmd *cobra.Command, args []string) {
+	determineService()
+	loading.Stop()
+
+	fire, ok := service.(*services.FirebaseService)
+	if !ok {
+		pkg.Alert(pkg.ErrorL, assets.NotAvailableForLocal.Error())
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	events, errs := fire.Subscribe(ctx, models.NotyaIgnoreFiles)
+	if !watchJSON {
+		pkg.Print(fmt.Sprintf("Watching %v, press Ctrl+C to stop", fire.Config.FirePath()), color.FgHiBlack)
+	}
+
+	for events != nil || errs != nil {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+
+			if watchJSON {
+				fmt.Fprintln(stdargs.Stdout, event.ToString())
+			} else {
+				pkg.PrintEvent(event)
+			}
+
+			if !watchApply {
+				continue
+			}
+
+			if err := services.ApplyEvent(localService, event); err != nil {
+				pkg.PrintErrors("apply", []error{assets.CannotDoSth("apply", event.Node.Title, err)})
+			}
+
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+
+			pkg.Alert(pkg.ErrorL, err.Error())
+		}
+	}
+}
@@ -31,6 +31,7 @@ func runWhereCommand(cmd *cobra.Command, args []string) {
 	if len(args) > 0 {
 		note, err := service.View(models.Note{Title: args[0]})
 		loading.Stop()
+		warnIfStale()
 
 		if err != nil {
 			pkg.Alert(pkg.ErrorL, err.Error())
@@ -43,6 +44,7 @@ func runWhereCommand(cmd *cobra.Command, args []string) {
 
 	nodes, noteNames, err := service.GetAll("", "", models.NotyaIgnoreFiles)
 	loading.Stop()
+	warnIfStale()
 	if err != nil {
 		pkg.Alert(pkg.ErrorL, err.Error())
 		return
@@ -0,0 +1,122 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Checkpoint is a high-water mark of the last successful fetch
+// from a concrete remote service, with the content hashes of synced nodes.
+//
+//	Example:
+//
+// ╭─────────────────────────────────────────╮
+// │ Service: FIREBASE                       │
+// │ Collection: nt-notes                    │
+// │ Time: 2021-12-08 14:43:12.1236 +0000 UTC │
+// │ Hashes: { "todo": "", "todo/a.md": ... } │
+// ╰─────────────────────────────────────────╯
+type Checkpoint struct {
+	// Service is the type of remote service, that checkpoint belongs to.
+	Service string `json:"service"`
+
+	// Collection is the name of remote base collection(or path),
+	// that was used at the moment of fetching.
+	// If the collection has changed, checkpoint gets invalid.
+	Collection string `json:"collection"`
+
+	// Time is the latest modification time of fetched nodes.
+	Time time.Time `json:"time"`
+
+	// Hashes are the content hashes of nodes at their last sync(push or fetch),
+	// keyed by trimmed titles. Folders are recorded with empty hashes.
+	// Used as the common base of local and remote nodes, to find the changed side.
+	Hashes map[string]string `json:"hashes,omitempty"`
+}
+
+// Record saves the [hash] of node of [key], as its last synced content.
+func (c *Checkpoint) Record(key, hash string) {
+	if c.Hashes == nil {
+		c.Hashes = map[string]string{}
+	}
+
+	c.Hashes[key] = hash
+}
+
+// ChangedSide decides which side changed the node of [key] since its last sync,
+// by comparing [local] and [remote] hashes of node with its recorded hash.
+//
+// Result is empty if hashes are the same, [LocalSide] or [RemoteSide] if only one
+// of them differs from the recorded hash, and [BothSides] if both differ or node
+// has never been synced.
+func (c *Checkpoint) ChangedSide(key, local, remote string) string {
+	if local == remote {
+		return ""
+	}
+
+	base, synced := c.Hashes[key]
+	switch {
+	case !synced:
+		return BothSides
+	case local == base:
+		return RemoteSide
+	case remote == base:
+		return LocalSide
+	}
+
+	return BothSides
+}
+
+// DirectedSide decides the changed side of node of [key] like [Checkpoint.ChangedSide],
+// for a sync directed from the [source] side(push from [LocalSide], fetch from [RemoteSide]).
+// Nodes that have never been synced(e.g. synced before hashes were recorded) are
+// considered changed only at [source], so the direction of sync wins for them.
+func (c *Checkpoint) DirectedSide(key, local, remote, source string) string {
+	if _, synced := c.Hashes[key]; !synced && local != remote {
+		return source
+	}
+
+	return c.ChangedSide(key, local, remote)
+}
+
+// Checkpoints is a map of checkpoints, keyed by service type.
+type Checkpoints map[string]Checkpoint
+
+// Get returns the valid checkpoint of provided [service] and [collection].
+// If checkpoint doesn't exists or collection of it was changed, result will
+// be a zero-valued checkpoint, which means "fetch everything".
+func (c Checkpoints) Get(service, collection string) Checkpoint {
+	checkpoint, ok := c[service]
+	if !ok || checkpoint.Collection != collection {
+		return Checkpoint{Service: service, Collection: collection}
+	}
+
+	return checkpoint
+}
+
+// ToString converts checkpoints to a formatted JSON string.
+func (c Checkpoints) ToString() string {
+	jsonBytes, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return ""
+	}
+
+	return string(jsonBytes)
+}
+
+// DecodeCheckpoints converts string(map) value to Checkpoints.
+// Invalid data results an empty checkpoints map.
+func DecodeCheckpoints(value string) Checkpoints {
+	c := Checkpoints{}
+	if err := json.Unmarshal([]byte(value), &c); err != nil {
+		return Checkpoints{}
+	}
+
+	return c
+}
@@ -0,0 +1,136 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/insolite-dev/nt/lib/models"
+)
+
+func TestCheckpointsGet(t *testing.T) {
+	mark := time.Date(2021, 12, 8, 14, 43, 12, 0, time.UTC)
+	checkpoints := models.Checkpoints{
+		"FIREBASE": {Service: "FIREBASE", Collection: "nt-notes", Time: mark},
+	}
+
+	tests := []struct {
+		testname            string
+		service, collection string
+		expected            time.Time
+	}{
+		{
+			testname:   "should return saved checkpoint",
+			service:    "FIREBASE",
+			collection: "nt-notes",
+			expected:   mark,
+		},
+		{
+			testname:   "should return zero checkpoint for migrated collection",
+			service:    "FIREBASE",
+			collection: "nt-notes-v2",
+			expected:   time.Time{},
+		},
+		{
+			testname:   "should return zero checkpoint for unknown service",
+			service:    "LOCAL",
+			collection: "nt-notes",
+			expected:   time.Time{},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testname, func(t *testing.T) {
+			got := checkpoints.Get(td.service, td.collection)
+			if !got.Time.Equal(td.expected) {
+				t.Errorf("Checkpoints.Get sum was different: Want: %v | Got: %v", td.expected, got.Time)
+			}
+		})
+	}
+}
+
+func TestCheckpointChangedSide(t *testing.T) {
+	checkpoint := models.Checkpoint{Service: "FIREBASE", Collection: "nt-notes"}
+	checkpoint.Record("todo.md", "base")
+
+	tests := []struct {
+		testname           string
+		key, local, remote string
+		expected           string
+	}{
+		{testname: "should be empty for same hashes", key: "todo.md", local: "a", remote: "a", expected: ""},
+		{testname: "should be local side", key: "todo.md", local: "a", remote: "base", expected: models.LocalSide},
+		{testname: "should be remote side", key: "todo.md", local: "base", remote: "a", expected: models.RemoteSide},
+		{testname: "should be both sides", key: "todo.md", local: "a", remote: "b", expected: models.BothSides},
+		{testname: "should be both sides for unsynced node", key: "ideas.md", local: "a", remote: "b", expected: models.BothSides},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testname, func(t *testing.T) {
+			got := checkpoint.ChangedSide(td.key, td.local, td.remote)
+			if got != td.expected {
+				t.Errorf("Checkpoint.ChangedSide sum was different: Want: %v | Got: %v", td.expected, got)
+			}
+		})
+	}
+}
+
+func TestCheckpointDirectedSide(t *testing.T) {
+	checkpoint := models.Checkpoint{Service: "FIREBASE", Collection: "nt-notes"}
+	checkpoint.Record("todo.md", "base")
+
+	tests := []struct {
+		testname           string
+		key, local, remote string
+		source             string
+		expected           string
+	}{
+		{testname: "should be empty for same hashes", key: "ideas.md", local: "a", remote: "a", source: models.LocalSide, expected: ""},
+		{testname: "should be both sides for synced node", key: "todo.md", local: "a", remote: "b", source: models.LocalSide, expected: models.BothSides},
+		{testname: "should be remote side for synced node", key: "todo.md", local: "base", remote: "a", source: models.LocalSide, expected: models.RemoteSide},
+		{testname: "should be local side for unsynced pushed node", key: "ideas.md", local: "a", remote: "b", source: models.LocalSide, expected: models.LocalSide},
+		{testname: "should be remote side for unsynced fetched node", key: "ideas.md", local: "a", remote: "b", source: models.RemoteSide, expected: models.RemoteSide},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testname, func(t *testing.T) {
+			got := checkpoint.DirectedSide(td.key, td.local, td.remote, td.source)
+			if got != td.expected {
+				t.Errorf("Checkpoint.DirectedSide sum was different: Want: %v | Got: %v", td.expected, got)
+			}
+		})
+	}
+}
+
+func TestDecodeCheckpoints(t *testing.T) {
+	tests := []struct {
+		testname string
+		value    string
+		expected int
+	}{
+		{
+			testname: "should decode checkpoints properly",
+			value:    `{"FIREBASE": {"service": "FIREBASE", "collection": "nt", "time": "2021-12-08T14:43:12Z"}}`,
+			expected: 1,
+		},
+		{
+			testname: "should return empty checkpoints for invalid data",
+			value:    `not-a-json`,
+			expected: 0,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testname, func(t *testing.T) {
+			got := models.DecodeCheckpoints(td.value)
+			if len(got) != td.expected {
+				t.Errorf("DecodeCheckpoints sum was different: Want: %v | Got: %v", td.expected, len(got))
+			}
+		})
+	}
+}
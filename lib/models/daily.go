@@ -0,0 +1,70 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models
+
+import (
+	"path"
+	"strings"
+	"time"
+)
+
+// Default layout of daily journal notes.
+const (
+	// DefaultJournalFolder is the folder of daily notes, at the root of notes.
+	DefaultJournalFolder = "journal"
+
+	// DefaultJournalFormat is the time layout of daily notes' titles, relative to journal folder.
+	// Like: 2026/10/2026-10-18.md
+	DefaultJournalFormat = "2006/01/2006-01-02.md"
+)
+
+// DailyNote is a note of daily journal, with the date that it belongs to.
+type DailyNote struct {
+	Date time.Time `json:"date"`
+	Node Node      `json:"node"`
+}
+
+// JournalRoot returns valid folder of daily journal notes.
+func (s *Settings) JournalRoot() string {
+	if folder := strings.Trim(s.JournalFolder, "/"); len(folder) > 0 {
+		return folder
+	}
+
+	return DefaultJournalFolder
+}
+
+// JournalLayout returns valid time layout of daily journal notes' titles.
+func (s *Settings) JournalLayout() string {
+	if layout := strings.Trim(s.JournalFormat, "/"); len(layout) > 0 {
+		return layout
+	}
+
+	return DefaultJournalFormat
+}
+
+// JournalTitle generates the title of daily note, that belongs to the [date].
+func (s *Settings) JournalTitle(date time.Time) string {
+	return path.Join(s.JournalRoot(), date.Format(s.JournalLayout()))
+}
+
+// JournalDate parses the date of daily note from its [title].
+// Result is false, if title doesn't belong to a daily note.
+func (s *Settings) JournalDate(title string) (time.Time, bool) {
+	title = strings.Trim(title, "/")
+
+	prefix := s.JournalRoot() + "/"
+	if !strings.HasPrefix(title, prefix) {
+		return time.Time{}, false
+	}
+
+	date, err := time.ParseInLocation(s.JournalLayout(), strings.TrimPrefix(title, prefix), time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return date, true
+}
@@ -0,0 +1,56 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/insolite-dev/nt/lib/models"
+)
+
+func TestJournalTitleAndDate(t *testing.T) {
+	date := time.Date(2026, 10, 18, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		testname string
+		settings models.Settings
+		expected string
+	}{
+		{
+			testname: "should use default journal layout",
+			settings: models.Settings{},
+			expected: "journal/2026/10/2026-10-18.md",
+		},
+		{
+			testname: "should use custom journal layout",
+			settings: models.Settings{JournalFolder: "/logs/", JournalFormat: "2006-01-02.txt"},
+			expected: "logs/2026-10-18.txt",
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testname, func(t *testing.T) {
+			title := td.settings.JournalTitle(date)
+			if title != td.expected {
+				t.Errorf("JournalTitle sum was different: Want: %v | Got: %v", td.expected, title)
+			}
+
+			got, ok := td.settings.JournalDate("/" + title)
+			if !ok || !got.Equal(date) {
+				t.Errorf("JournalDate sum was different: Want: %v | Got: %v, %v", date, got, ok)
+			}
+		})
+	}
+
+	settings := models.Settings{}
+	for _, title := range []string{"journal/todo.md", "work/2026/10/2026-10-18.md", "journal/2026/10/2026-10-18.md.bak"} {
+		if _, ok := settings.JournalDate(title); ok {
+			t.Errorf("JournalDate of %v must fail", title)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models
+
+// Kinds of diagnoses, that could be found by doctor.
+const (
+	// OrphanDiagnosis is a node(or nodes) left without its parent folder.
+	OrphanDiagnosis = "orphan"
+
+	// PathMismatchDiagnosis is a node which's stored path disagrees with its real location.
+	PathMismatchDiagnosis = "path-mismatch"
+
+	// UndecodableDiagnosis is a data that couldn't be decoded as a node.
+	UndecodableDiagnosis = "undecodable"
+
+	// InvalidSettingsDiagnosis is a missing or invalid field of settings.
+	InvalidSettingsDiagnosis = "invalid-settings"
+
+	// UnreadableDiagnosis is a file or folder that couldn't be read.
+	UnreadableDiagnosis = "unreadable"
+
+	// BrokenLinkDiagnosis is a symlink which's target doesn't exist.
+	BrokenLinkDiagnosis = "broken-symlink"
+
+	// LinkLoopDiagnosis is a symlink that refers to itself or to one of its parents.
+	LinkLoopDiagnosis = "symlink-loop"
+
+	// IgnoredDiagnosis is a file that's hidden from nt, only because of its name.
+	IgnoredDiagnosis = "ignored-by-name"
+
+	// LeftoverDiagnosis is a temporary note, left after a crash of remote editing.
+	LeftoverDiagnosis = "leftover-temp"
+
+	// DanglingLinkDiagnosis is a link of note, that doesn't refer to an existing note.
+	DanglingLinkDiagnosis = "dangling-link"
+
+	// MissingTimestampDiagnosis is a document without modification time, which
+	// isn't found by incremental queries of changes.
+	MissingTimestampDiagnosis = "missing-timestamp"
+)
+
+// Diagnosis is a single problem of service, found by doctor.
+//
+//	Example:
+//
+// ╭──────────────────────────────────────────────────────╮
+// │ Kind: orphan                                         │
+// │ Path: nt/todo/                                       │
+// │ Message: 3 documents left without parent folder      │
+// │ Suggestion: Run doctor with --fix to remove them     │
+// │ Fixed: false                                         │
+// ╰──────────────────────────────────────────────────────╯
+type Diagnosis struct {
+	// Kind is the short name of problem's category.
+	Kind string `json:"kind"`
+
+	// Path is the location of problem.
+	Path string `json:"path"`
+
+	// Message is the human readable explanation of problem.
+	Message string `json:"message"`
+
+	// Suggestion is an actionable hint to resolve the problem.
+	Suggestion string `json:"suggestion,omitempty"`
+
+	// Fixed represents whether the problem was repaired by doctor or not.
+	Fixed bool `json:"fixed"`
+}
+
+// DoctorReport is a collection of diagnoses, that doctor found.
+type DoctorReport struct {
+	Diagnoses []Diagnosis `json:"diagnoses"`
+}
+
+// Add appends given diagnosis to the report.
+func (r *DoctorReport) Add(d Diagnosis) {
+	r.Diagnoses = append(r.Diagnoses, d)
+}
+
+// Counts returns the number of diagnoses per kind.
+func (r *DoctorReport) Counts() map[string]int {
+	counts := map[string]int{}
+	for _, d := range r.Diagnoses {
+		counts[d.Kind]++
+	}
+
+	return counts
+}
+
+// Unresolved returns the number of diagnoses that weren't fixed.
+func (r *DoctorReport) Unresolved() int {
+	count := 0
+	for _, d := range r.Diagnoses {
+		if !d.Fixed {
+			count++
+		}
+	}
+
+	return count
+}
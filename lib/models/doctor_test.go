@@ -0,0 +1,29 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models_test
+
+import (
+	"testing"
+
+	"github.com/insolite-dev/nt/lib/models"
+)
+
+func TestDoctorReport(t *testing.T) {
+	report := models.DoctorReport{}
+	report.Add(models.Diagnosis{Kind: "orphan", Path: "nt/todo/"})
+	report.Add(models.Diagnosis{Kind: "orphan", Path: "nt/ideas/", Fixed: true})
+	report.Add(models.Diagnosis{Kind: "undecodable", Path: "nt/broken"})
+
+	counts := report.Counts()
+	if counts["orphan"] != 2 || counts["undecodable"] != 1 {
+		t.Errorf("DoctorReport.Counts sum was different: Got: %v", counts)
+	}
+
+	if got := report.Unresolved(); got != 2 {
+		t.Errorf("DoctorReport.Unresolved sum was different: Want: %v | Got: %v", 2, got)
+	}
+}
@@ -0,0 +1,69 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Kinds of remote change events.
+const (
+	CreatedEvent = "created"
+	UpdatedEvent = "updated"
+	RemovedEvent = "removed"
+)
+
+// Event is a single change of remote node, observed in realtime.
+//
+//	Example:
+//
+// ╭──────────────────────────────────────────────────────╮
+// │ Kind: updated                                        │
+// │ Service: FIREBASE                                    │
+// │ Path: nt/todo/sub/today.md                           │
+// │ Node: {typ: FILE, title: todo/today.md, ...}         │
+// │ Time: 2021-12-08 14:43:12 +0000 UTC                  │
+// ╰──────────────────────────────────────────────────────╯
+type Event struct {
+	// Kind is the type of change, one of created/updated/removed.
+	Kind string `json:"kind"`
+
+	// Service is the type of service, that event was observed at.
+	Service string `json:"service"`
+
+	// Path is the full path of changed document.
+	Path string `json:"path"`
+
+	// Node is the state of changed node, for removed nodes
+	// it's the last known state before removal.
+	Node Node `json:"node"`
+
+	// Time is the time of change.
+	Time time.Time `json:"time"`
+}
+
+// ToString converts event to a single line JSON string.
+func (e *Event) ToString() string {
+	jsonBytes, err := json.Marshal(e)
+	if err != nil {
+		return ""
+	}
+
+	return string(jsonBytes)
+}
+
+// DecodeEvent converts single line JSON string to Event.
+// Invalid data results a zero-valued event.
+func DecodeEvent(value string) Event {
+	var e Event
+	if err := json.Unmarshal([]byte(value), &e); err != nil {
+		return Event{}
+	}
+
+	return e
+}
@@ -0,0 +1,61 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/insolite-dev/nt/lib/models"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	event := models.Event{
+		Kind:    models.UpdatedEvent,
+		Service: "FIREBASE",
+		Path:    "nt/todo/sub/today.md",
+		Node: models.Node{
+			Type:  models.FILE,
+			Title: "todo/today.md",
+			Path:  map[string]string{"FIREBASE": "nt/todo/today.md"},
+			Body:  "first line\nsecond line",
+		},
+		Time: time.Date(2021, 12, 8, 14, 43, 12, 0, time.UTC),
+	}
+
+	if line := event.ToString(); strings.Contains(line, "\n") {
+		t.Errorf("Event.ToString must be a single line: Got: %v", line)
+	}
+
+	tests := []struct {
+		testname string
+		value    string
+		expected models.Event
+	}{
+		{
+			testname: "should decode event properly",
+			value:    event.ToString(),
+			expected: event,
+		},
+		{
+			testname: "should return zero event for invalid data",
+			value:    `not-a-json`,
+			expected: models.Event{},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testname, func(t *testing.T) {
+			got := models.DecodeEvent(td.value)
+			if !reflect.DeepEqual(got, td.expected) {
+				t.Errorf("DecodeEvent sum was different: Want: %v | Got: %v", td.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/insolite-dev/nt/assets"
+	"gopkg.in/yaml.v3"
+)
+
+// FrontMatterDelimiter is the line that opens and closes the front matter of note.
+const FrontMatterDelimiter = "---"
+
+// Keys of front matter, that have a structured meaning for application.
+const (
+	TitleKey   = "title"
+	TagsKey    = "tags"
+	AliasesKey = "aliases"
+)
+
+// FrontMatter is the YAML metadata block at the top of note.
+// Keys that aren't known by application are kept at [Fields].
+//
+//	Example:
+//
+// ╭─────────────────────────────────────────────╮
+// │ ---                                         │
+// │ title: Weekly review                        │
+// │ tags: [work, review]                        │
+// │ aliases: [review]                           │
+// │ status: draft                               │
+// │ ---                                         │
+// │ ... Note content here ...                   │
+// ╰─────────────────────────────────────────────╯
+type FrontMatter struct {
+	Title   string                 `json:"title,omitempty"`
+	Tags    []string               `json:"tags,omitempty"`
+	Aliases []string               `json:"aliases,omitempty"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+
+	// doc is the parsed YAML document of front matter. Editing is made
+	// on it, to keep the order and comments of untouched keys.
+	doc *yaml.Node
+}
+
+// SplitFrontMatter separates the raw front matter block from the content of body.
+// If body doesn't start with a front matter, [ok] is false and content is the whole body.
+func SplitFrontMatter(body string) (raw string, content string, ok bool) {
+	lines := strings.SplitAfter(body, "\n")
+	if len(lines) < 2 || !isDelimiter(lines[0], FrontMatterDelimiter) {
+		return "", body, false
+	}
+
+	for i := 1; i < len(lines); i++ {
+		if isDelimiter(lines[i], FrontMatterDelimiter) || isDelimiter(lines[i], "...") {
+			return strings.Join(lines[1:i], ""), strings.Join(lines[i+1:], ""), true
+		}
+	}
+
+	return "", body, false
+}
+
+// isDelimiter checks if given line is the provided delimiter of front matter.
+func isDelimiter(line, delimiter string) bool {
+	return strings.TrimRight(line, " \t\r\n") == delimiter
+}
+
+// ParseFrontMatter parses the front matter at the top of body.
+// Body without front matter results an empty front matter.
+func ParseFrontMatter(body string) (*FrontMatter, error) {
+	raw, _, _ := SplitFrontMatter(body)
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(raw), &doc); err != nil {
+		return nil, assets.InvalidFrontMatter
+	}
+
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, assets.InvalidFrontMatter
+	}
+
+	f := &FrontMatter{doc: &doc}
+	f.decode()
+
+	return f, nil
+}
+
+// root returns the mapping node of front matter.
+func (f *FrontMatter) root() *yaml.Node {
+	if f.doc == nil {
+		f.doc = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+
+	return f.doc.Content[0]
+}
+
+// decode re-generates the structured fields from the YAML document.
+func (f *FrontMatter) decode() {
+	f.Title, f.Tags, f.Aliases, f.Fields = "", nil, nil, map[string]interface{}{}
+
+	root := f.root()
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i].Value, root.Content[i+1]
+
+		switch key {
+		case TitleKey:
+			f.Title = value.Value
+		case TagsKey:
+			for _, tag := range listOf(value) {
+				f.Tags = append(f.Tags, strings.TrimPrefix(tag, "#"))
+			}
+		case AliasesKey:
+			f.Aliases = listOf(value)
+		default:
+			var v interface{}
+			_ = value.Decode(&v)
+			f.Fields[key] = v
+		}
+	}
+}
+
+// listOf returns the string items of a sequence node.
+// Scalar node is split by commas and spaces, as a short form of the list.
+func listOf(node *yaml.Node) []string {
+	var list []string
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if item.Kind == yaml.ScalarNode && len(item.Value) > 0 {
+				list = append(list, item.Value)
+			}
+		}
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" {
+			return nil
+		}
+
+		list = strings.FieldsFunc(node.Value, func(r rune) bool {
+			return r == ',' || r == ' '
+		})
+	}
+
+	return list
+}
+
+// Keys returns the keys of front matter, in the order they're written.
+func (f *FrontMatter) Keys() []string {
+	root := f.root()
+
+	keys := []string{}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		keys = append(keys, root.Content[i].Value)
+	}
+
+	return keys
+}
+
+// Get returns the value of given key as YAML text.
+// Scalar values are returned as they are, without quotes.
+func (f *FrontMatter) Get(key string) (string, bool) {
+	root := f.root()
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != key {
+			continue
+		}
+
+		value := root.Content[i+1]
+		if value.Kind == yaml.ScalarNode {
+			return value.Value, true
+		}
+
+		out, _ := yaml.Marshal(value)
+		return strings.TrimSuffix(string(out), "\n"), true
+	}
+
+	return "", false
+}
+
+// Set updates the value of given key, or appends it if it doesn't exist.
+// Value is parsed as YAML, so lists could be provided like: [a, b].
+func (f *FrontMatter) Set(key, value string) error {
+	if len(strings.TrimSpace(key)) == 0 {
+		return assets.InvalidMetaKey
+	}
+
+	node := valueNode(value)
+
+	root := f.root()
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == key {
+			node.HeadComment, node.LineComment = root.Content[i+1].HeadComment, root.Content[i+1].LineComment
+			root.Content[i+1] = node
+			f.decode()
+
+			return nil
+		}
+	}
+
+	root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, node)
+	f.decode()
+
+	return nil
+}
+
+// SetList sets given values as a flow list(like: [a, b]) to the key.
+// Key is removed, if there is no value.
+func (f *FrontMatter) SetList(key string, values []string) error {
+	if len(values) == 0 {
+		f.Unset(key)
+		return nil
+	}
+
+	list := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: yaml.FlowStyle}
+	for _, v := range values {
+		list.Content = append(list.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v})
+	}
+
+	out, _ := yaml.Marshal(list)
+	return f.Set(key, string(out))
+}
+
+// valueNode generates the YAML node of given value.
+// Only scalars and flow collections are parsed, any other value is kept as a plain string.
+func valueNode(value string) *yaml.Node {
+	trimmed := strings.TrimSpace(value)
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(trimmed), &doc); err == nil && len(doc.Content) > 0 {
+		node := doc.Content[0]
+		if node.Kind == yaml.ScalarNode || strings.HasPrefix(trimmed, "[") || strings.HasPrefix(trimmed, "{") {
+			return node
+		}
+	}
+
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// Unset removes given key from front matter.
+// Returns false, if key doesn't exist.
+func (f *FrontMatter) Unset(key string) bool {
+	root := f.root()
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == key {
+			root.Content = append(root.Content[:i], root.Content[i+2:]...)
+			f.decode()
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// ToString returns the YAML text of front matter, without delimiters.
+func (f *FrontMatter) ToString() string {
+	if len(f.root().Content) == 0 {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	_ = encoder.Encode(f.doc)
+	_ = encoder.Close()
+
+	return buf.String()
+}
+
+// Apply replaces the front matter of given body with the current one.
+// Content of body is kept as it is, and the block is removed if front matter is empty.
+func (f *FrontMatter) Apply(body string) string {
+	_, content, _ := SplitFrontMatter(body)
+
+	raw := f.ToString()
+	if len(raw) == 0 {
+		return content
+	}
+
+	return FrontMatterDelimiter + "\n" + raw + FrontMatterDelimiter + "\n" + content
+}
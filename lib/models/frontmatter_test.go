@@ -0,0 +1,187 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/insolite-dev/nt/assets"
+	"github.com/insolite-dev/nt/lib/models"
+)
+
+func TestSplitFrontMatter(t *testing.T) {
+	tests := []struct {
+		body            string
+		expectedRaw     string
+		expectedContent string
+		expectedOk      bool
+	}{
+		{
+			body:            "---\ntitle: x\n---\nbody\n",
+			expectedRaw:     "title: x\n",
+			expectedContent: "body\n",
+			expectedOk:      true,
+		},
+		{
+			body:            "---\r\ntitle: x\r\n...\r\nbody",
+			expectedRaw:     "title: x\r\n",
+			expectedContent: "body",
+			expectedOk:      true,
+		},
+		{
+			body:            "---\n---\n",
+			expectedRaw:     "",
+			expectedContent: "",
+			expectedOk:      true,
+		},
+		{
+			body:            "just a note\n---\ntitle: x\n---\n",
+			expectedRaw:     "",
+			expectedContent: "just a note\n---\ntitle: x\n---\n",
+			expectedOk:      false,
+		},
+		{
+			body:            "---\nnot closed\n",
+			expectedRaw:     "",
+			expectedContent: "---\nnot closed\n",
+			expectedOk:      false,
+		},
+	}
+
+	for _, td := range tests {
+		raw, content, ok := models.SplitFrontMatter(td.body)
+		if raw != td.expectedRaw || content != td.expectedContent || ok != td.expectedOk {
+			t.Errorf("SplitFrontMatter of %q was different: Got: %q, %q, %v", td.body, raw, content, ok)
+		}
+	}
+}
+
+func TestParseFrontMatter(t *testing.T) {
+	tests := []struct {
+		body     string
+		expected *models.FrontMatter
+		err      error
+	}{
+		{
+			body: "---\ntitle: Weekly review\ntags: [work, \"#review\"]\naliases:\n  - review\nstatus: draft\n---\nbody",
+			expected: &models.FrontMatter{
+				Title:   "Weekly review",
+				Tags:    []string{"work", "review"},
+				Aliases: []string{"review"},
+				Fields:  map[string]interface{}{"status": "draft"},
+			},
+		},
+		{
+			body: "---\ntags: work, review\n---\n",
+			expected: &models.FrontMatter{
+				Tags:   []string{"work", "review"},
+				Fields: map[string]interface{}{},
+			},
+		},
+		{
+			body:     "no front matter",
+			expected: &models.FrontMatter{Fields: map[string]interface{}{}},
+		},
+		{
+			body: "---\n- a\n- b\n---\n",
+			err:  assets.InvalidFrontMatter,
+		},
+		{
+			body: "---\ntitle: [unclosed\n---\n",
+			err:  assets.InvalidFrontMatter,
+		},
+	}
+
+	for _, td := range tests {
+		got, err := models.ParseFrontMatter(td.body)
+		if err != td.err {
+			t.Errorf("ParseFrontMatter error of %q was different: Want: %v | Got: %v", td.body, td.err, err)
+			continue
+		}
+
+		if err != nil {
+			continue
+		}
+
+		if got.Title != td.expected.Title ||
+			!reflect.DeepEqual(got.Tags, td.expected.Tags) ||
+			!reflect.DeepEqual(got.Aliases, td.expected.Aliases) ||
+			!reflect.DeepEqual(got.Fields, td.expected.Fields) {
+			t.Errorf("ParseFrontMatter of %q was different: Want: %v | Got: %v", td.body, td.expected, got)
+		}
+	}
+}
+
+func TestFrontMatterEditing(t *testing.T) {
+	body := "---\n# reviewed weekly\ntitle: Weekly review\nstatus: draft\n---\n# Content\n\n---\nrule\n"
+
+	meta, err := models.ParseFrontMatter(body)
+	if err != nil {
+		t.Fatalf("ParseFrontMatter failed: %v", err)
+	}
+
+	if value, ok := meta.Get("status"); !ok || value != "draft" {
+		t.Errorf("FrontMatter.Get was different: Got: %v, %v", value, ok)
+	}
+
+	if _, ok := meta.Get("missing"); ok {
+		t.Errorf("FrontMatter.Get of missing key must not exist")
+	}
+
+	if err := meta.Set("", "x"); err != assets.InvalidMetaKey {
+		t.Errorf("FrontMatter.Set of empty key was different: Want: %v | Got: %v", assets.InvalidMetaKey, err)
+	}
+
+	_ = meta.Set("status", "done")
+	_ = meta.Set("tags", "[work, review]")
+	_ = meta.Set("summary", "a: b")
+
+	if !reflect.DeepEqual(meta.Tags, []string{"work", "review"}) {
+		t.Errorf("FrontMatter.Set didn't update tags: Got: %v", meta.Tags)
+	}
+
+	if !reflect.DeepEqual(meta.Keys(), []string{"title", "status", "tags", "summary"}) {
+		t.Errorf("FrontMatter.Keys was different: Got: %v", meta.Keys())
+	}
+
+	expected := "---\n# reviewed weekly\ntitle: Weekly review\nstatus: done\ntags: [work, review]\nsummary: 'a: b'\n---\n# Content\n\n---\nrule\n"
+	if got := meta.Apply(body); got != expected {
+		t.Errorf("FrontMatter.Apply was different: Want: %q | Got: %q", expected, got)
+	}
+
+	if meta.Unset("missing") {
+		t.Errorf("FrontMatter.Unset of missing key must return false")
+	}
+
+	for _, key := range []string{"title", "status", "tags", "summary"} {
+		if !meta.Unset(key) {
+			t.Errorf("FrontMatter.Unset of %v must return true", key)
+		}
+	}
+
+	if got := meta.Apply(body); got != "# Content\n\n---\nrule\n" {
+		t.Errorf("FrontMatter.Apply of empty front matter was different: Got: %q", got)
+	}
+
+	list, _ := models.ParseFrontMatter("---\ntags: a\n---\n")
+	_ = list.SetList("tags", []string{"a", "b c"})
+	if got := list.Apply(""); got != "---\ntags: [a, b c]\n---\n" {
+		t.Errorf("FrontMatter.SetList was different: Got: %q", got)
+	}
+
+	_ = list.SetList("tags", nil)
+	if _, ok := list.Get("tags"); ok {
+		t.Errorf("FrontMatter.SetList of empty values must remove the key")
+	}
+
+	empty, _ := models.ParseFrontMatter("body")
+	_ = empty.Set("title", "New")
+	if got := empty.Apply("body"); got != "---\ntitle: New\n---\nbody" {
+		t.Errorf("FrontMatter.Apply of new front matter was different: Got: %q", got)
+	}
+}
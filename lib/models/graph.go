@@ -0,0 +1,273 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/insolite-dev/nt/assets"
+)
+
+// Kinds of edges at the graph of notes.
+const (
+	// LinkEdge is a link from a note to another note.
+	LinkEdge = "link"
+
+	// ContainsEdge is the containment of a node by its parent folder.
+	ContainsEdge = "contains"
+)
+
+// Formats that graph could be exported to.
+const (
+	DOTFormat     = "dot"
+	MermaidFormat = "mermaid"
+	JSONFormat    = "json"
+)
+
+// GraphNode is a note or folder at the graph of notes.
+type GraphNode struct {
+	// ID is the title of node, without leading and trailing slashes.
+	ID string `json:"id"`
+
+	// Type is the type of node, [FILE] or [FOLDER].
+	Type NodeType `json:"type"`
+}
+
+// GraphEdge is a directed connection between two nodes of graph.
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Kind string `json:"kind"`
+}
+
+// Graph is the graph of notes, by their links and folder containment.
+//
+//	Example:
+//
+// ╭─────────────────────────────────────────────╮
+// │ Nodes: [work (FOLDER), work/todo.md (FILE)] │
+// │ Edges: [work → work/todo.md (contains)]     │
+// ╰─────────────────────────────────────────────╯
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// Label returns the name of node, as it's shown at exported diagrams.
+func (n *GraphNode) Label() string {
+	label := n.ID[strings.LastIndex(n.ID, "/")+1:]
+	if n.Type == FOLDER {
+		return label + "/"
+	}
+
+	return label
+}
+
+// SubGraph returns the part of graph, that's reachable from the node of [root] by
+// at most [depth] edges. Links are followed at both directions, and containment
+// only from folders to their children. Non-positive depth means no limit.
+func (g *Graph) SubGraph(root string, depth int) (*Graph, error) {
+	root = strings.Trim(root, "/")
+
+	found := false
+	for _, node := range g.Nodes {
+		found = found || node.ID == root
+	}
+
+	if !found {
+		return nil, assets.NotExists(root, "File or Directory")
+	}
+
+	neighbours := map[string][]string{}
+	for _, edge := range g.Edges {
+		neighbours[edge.From] = append(neighbours[edge.From], edge.To)
+		if edge.Kind == LinkEdge {
+			neighbours[edge.To] = append(neighbours[edge.To], edge.From)
+		}
+	}
+
+	visited := map[string]bool{root: true}
+	queue := []string{root}
+	for level := 0; len(queue) > 0 && (depth <= 0 || level < depth); level++ {
+		next := []string{}
+		for _, id := range queue {
+			for _, n := range neighbours[id] {
+				if !visited[n] {
+					visited[n] = true
+					next = append(next, n)
+				}
+			}
+		}
+
+		queue = next
+	}
+
+	sub := &Graph{Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	for _, node := range g.Nodes {
+		if visited[node.ID] {
+			sub.Nodes = append(sub.Nodes, node)
+		}
+	}
+
+	for _, edge := range g.Edges {
+		if visited[edge.From] && visited[edge.To] {
+			sub.Edges = append(sub.Edges, edge)
+		}
+	}
+
+	return sub, nil
+}
+
+// Orphans returns the notes of graph, that don't link to and aren't linked by any other note.
+func (g *Graph) Orphans() []string {
+	linked := map[string]bool{}
+	for _, edge := range g.Edges {
+		if edge.Kind == LinkEdge {
+			linked[edge.From], linked[edge.To] = true, true
+		}
+	}
+
+	orphans := []string{}
+	for _, node := range g.Nodes {
+		if node.Type == FILE && !linked[node.ID] {
+			orphans = append(orphans, node.ID)
+		}
+	}
+
+	return orphans
+}
+
+// Clusters returns the groups of notes, that are connected by links(at any direction).
+// Orphan notes aren't clusters. Clusters are sorted by their size, biggest first.
+func (g *Graph) Clusters() [][]string {
+	parent := map[string]string{}
+
+	var find func(id string) string
+	find = func(id string) string {
+		if parent[id] != id {
+			parent[id] = find(parent[id])
+		}
+
+		return parent[id]
+	}
+
+	for _, edge := range g.Edges {
+		if edge.Kind != LinkEdge {
+			continue
+		}
+
+		for _, id := range []string{edge.From, edge.To} {
+			if _, ok := parent[id]; !ok {
+				parent[id] = id
+			}
+		}
+
+		parent[find(edge.From)] = find(edge.To)
+	}
+
+	groups := map[string][]string{}
+	for _, node := range g.Nodes {
+		if _, ok := parent[node.ID]; ok {
+			root := find(node.ID)
+			groups[root] = append(groups[root], node.ID)
+		}
+	}
+
+	clusters := [][]string{}
+	for _, group := range groups {
+		clusters = append(clusters, group)
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		if len(clusters[i]) != len(clusters[j]) {
+			return len(clusters[i]) > len(clusters[j])
+		}
+
+		return clusters[i][0] < clusters[j][0]
+	})
+
+	return clusters
+}
+
+// Export converts graph to the given [format], [DOTFormat], [MermaidFormat] or [JSONFormat].
+func (g *Graph) Export(format string) (string, error) {
+	switch strings.ToLower(format) {
+	case DOTFormat:
+		return g.ToDOT(), nil
+	case MermaidFormat:
+		return g.ToMermaid(), nil
+	case JSONFormat:
+		jsonBytes, err := json.MarshalIndent(g, "", "  ")
+		if err != nil {
+			return "", err
+		}
+
+		return string(jsonBytes), nil
+	}
+
+	return "", assets.InvalidGraphFormat
+}
+
+// ToDOT converts graph to a Graphviz DOT diagram.
+// Folders are drawn as folder shapes, and containment edges as dashed lines.
+func (g *Graph) ToDOT() string {
+	lines := []string{"digraph notes {", "  rankdir=LR;"}
+
+	for _, node := range g.Nodes {
+		shape := "note"
+		if node.Type == FOLDER {
+			shape = "folder"
+		}
+
+		lines = append(lines, fmt.Sprintf("  %v [label=%v, shape=%v];", strconv.Quote(node.ID), strconv.Quote(node.Label()), shape))
+	}
+
+	for _, edge := range g.Edges {
+		attrs := ""
+		if edge.Kind == ContainsEdge {
+			attrs = " [style=dashed, arrowhead=none]"
+		}
+
+		lines = append(lines, fmt.Sprintf("  %v -> %v%v;", strconv.Quote(edge.From), strconv.Quote(edge.To), attrs))
+	}
+
+	return strings.Join(append(lines, "}"), "\n")
+}
+
+// ToMermaid converts graph to a Mermaid flowchart, that could be embedded to markdown.
+// Folders are drawn as subroutine shapes, and containment edges as dotted lines.
+func (g *Graph) ToMermaid() string {
+	lines := []string{"graph LR"}
+
+	// Titles could contain any character, so nodes are identified by their index.
+	ids := map[string]string{}
+	for i, node := range g.Nodes {
+		ids[node.ID] = fmt.Sprintf("n%v", i)
+
+		label := strings.ReplaceAll(node.Label(), `"`, "#quot;")
+		if node.Type == FOLDER {
+			lines = append(lines, fmt.Sprintf(`  %v[["%v"]]`, ids[node.ID], label))
+		} else {
+			lines = append(lines, fmt.Sprintf(`  %v["%v"]`, ids[node.ID], label))
+		}
+	}
+
+	for _, edge := range g.Edges {
+		arrow := "-->"
+		if edge.Kind == ContainsEdge {
+			arrow = "-.-"
+		}
+
+		lines = append(lines, fmt.Sprintf("  %v %v %v", ids[edge.From], arrow, ids[edge.To]))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,125 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/insolite-dev/nt/assets"
+	"github.com/insolite-dev/nt/lib/models"
+)
+
+func mockGraph() *models.Graph {
+	return &models.Graph{
+		Nodes: []models.GraphNode{
+			{ID: "work", Type: models.FOLDER},
+			{ID: "work/todo.md", Type: models.FILE},
+			{ID: "work/plan.md", Type: models.FILE},
+			{ID: "journal.md", Type: models.FILE},
+			{ID: "ideas.md", Type: models.FILE},
+			{ID: "a.md", Type: models.FILE},
+			{ID: "b.md", Type: models.FILE},
+		},
+		Edges: []models.GraphEdge{
+			{From: "work", To: "work/todo.md", Kind: models.ContainsEdge},
+			{From: "work", To: "work/plan.md", Kind: models.ContainsEdge},
+			{From: "journal.md", To: "work/todo.md", Kind: models.LinkEdge},
+			{From: "work/todo.md", To: "work/plan.md", Kind: models.LinkEdge},
+			{From: "a.md", To: "b.md", Kind: models.LinkEdge},
+		},
+	}
+}
+
+func TestSubGraph(t *testing.T) {
+	tests := []struct {
+		root     string
+		depth    int
+		expected []string
+		err      error
+	}{
+		{root: "journal.md", depth: 0, expected: []string{"work/todo.md", "work/plan.md", "journal.md"}},
+		{root: "journal.md", depth: 1, expected: []string{"work/todo.md", "journal.md"}},
+		{root: "work/", depth: 1, expected: []string{"work", "work/todo.md", "work/plan.md"}},
+		{root: "b.md", depth: 0, expected: []string{"a.md", "b.md"}},
+		{root: "missing.md", err: assets.NotExists("missing.md", "File or Directory")},
+	}
+
+	for _, td := range tests {
+		sub, err := mockGraph().SubGraph(td.root, td.depth)
+		if !reflect.DeepEqual(err, td.err) {
+			t.Errorf("SubGraph(%v) error is different, Want: %v | Got: %v", td.root, td.err, err)
+			continue
+		}
+
+		if err != nil {
+			continue
+		}
+
+		got := []string{}
+		for _, node := range sub.Nodes {
+			got = append(got, node.ID)
+		}
+
+		if !reflect.DeepEqual(got, td.expected) {
+			t.Errorf("SubGraph(%v, %v) sum is different, Want: %v | Got: %v", td.root, td.depth, td.expected, got)
+		}
+	}
+}
+
+func TestOrphansAndClusters(t *testing.T) {
+	graph := mockGraph()
+
+	if got, expected := graph.Orphans(), []string{"ideas.md"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Orphans sum is different, Want: %v | Got: %v", expected, got)
+	}
+
+	expected := [][]string{{"work/todo.md", "work/plan.md", "journal.md"}, {"a.md", "b.md"}}
+	if got := graph.Clusters(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Clusters sum is different, Want: %v | Got: %v", expected, got)
+	}
+}
+
+func TestExportGraph(t *testing.T) {
+	tests := []struct {
+		format   string
+		contains []string
+		err      error
+	}{
+		{
+			format: models.DOTFormat,
+			contains: []string{
+				`"work" [label="work/", shape=folder];`,
+				`"work" -> "work/todo.md" [style=dashed, arrowhead=none];`,
+				`"journal.md" -> "work/todo.md";`,
+			},
+		},
+		{
+			format:   models.MermaidFormat,
+			contains: []string{"graph LR", `n0[["work/"]]`, `n1["todo.md"]`, "n0 -.- n1", "n3 --> n1"},
+		},
+		{
+			format:   "JSON",
+			contains: []string{`"id": "work/todo.md"`, `"kind": "contains"`},
+		},
+		{format: "svg", err: assets.InvalidGraphFormat},
+	}
+
+	for _, td := range tests {
+		got, err := mockGraph().Export(td.format)
+		if err != td.err {
+			t.Errorf("Export(%v) error is different, Want: %v | Got: %v", td.format, td.err, err)
+		}
+
+		for _, c := range td.contains {
+			if !strings.Contains(got, c) {
+				t.Errorf("Export(%v) must contain %v | Got: %v", td.format, c, got)
+			}
+		}
+	}
+}
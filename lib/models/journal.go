@@ -0,0 +1,101 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// JournalEntry is a record of an in-progress edit of a remote note.
+// It's written before the editor is opened, and removed after the
+// edited body is written back to the remote. So, an entry that is
+// left in the journal represents an edit, that could be recovered.
+//
+//	Example:
+//
+// ╭───────────────────────────────────────────────╮
+// │ ID: 3f1c9a0be27d4c11                          │
+// │ Service: FIREBASE                             │
+// │ Collection: nt-notes                          │
+// │ Title: todo/today.md                          │
+// │ File: /User/random-user/nt/.edits/3f1c...md   │
+// │ Hash: 9f86d081884c7d65...                     │
+// │ Started At: 2021-12-08 14:43:12 +0000 UTC     │
+// ╰───────────────────────────────────────────────╯
+type JournalEntry struct {
+	// ID is the unique key of edit, generated from service, collection and title of note.
+	ID string `json:"id"`
+
+	// Service is the type of remote service, that note belongs to.
+	Service string `json:"service"`
+
+	// Collection is the name of remote base collection of note.
+	Collection string `json:"collection"`
+
+	// Title is the title of remote note.
+	Title string `json:"title"`
+
+	// Path is the path of remote note, at the moment of reading.
+	Path map[string]string `json:"path,omitempty"`
+
+	// File is the local path of cached body, that's opened via editor.
+	File string `json:"file"`
+
+	// Hash is the content hash of original body of note.
+	// Used to skip write-back, if the body wasn't changed.
+	Hash string `json:"hash"`
+
+	// UpdatedAt is the last update time of remote note, at the moment of reading.
+	// Used as a precondition of write-back, to detect changes of teammates.
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// StartedAt is the time when editing was started.
+	StartedAt time.Time `json:"started_at"`
+}
+
+// Journal is a map of in-progress edits, keyed by their IDs.
+type Journal map[string]JournalEntry
+
+// Find returns the entries of provided [service] and [collection],
+// in ascending order of their start times.
+func (j Journal) Find(service, collection string) []JournalEntry {
+	entries := []JournalEntry{}
+	for _, e := range j {
+		if e.Service == service && e.Collection == collection {
+			entries = append(entries, e)
+		}
+	}
+
+	sort.Slice(entries, func(i, k int) bool {
+		return entries[i].StartedAt.Before(entries[k].StartedAt)
+	})
+
+	return entries
+}
+
+// ToString converts journal to a formatted JSON string.
+func (j Journal) ToString() string {
+	jsonBytes, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return ""
+	}
+
+	return string(jsonBytes)
+}
+
+// DecodeJournal converts string(map) value to Journal.
+// Invalid data results an empty journal.
+func DecodeJournal(value string) Journal {
+	j := Journal{}
+	if err := json.Unmarshal([]byte(value), &j); err != nil {
+		return Journal{}
+	}
+
+	return j
+}
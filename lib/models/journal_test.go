@@ -0,0 +1,60 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/insolite-dev/nt/lib/models"
+)
+
+func TestJournalFind(t *testing.T) {
+	mark := time.Date(2021, 12, 8, 14, 43, 12, 0, time.UTC)
+	journal := models.Journal{
+		"b": {ID: "b", Service: "FIREBASE", Collection: "nt", StartedAt: mark.Add(time.Minute)},
+		"a": {ID: "a", Service: "FIREBASE", Collection: "nt", StartedAt: mark},
+		"c": {ID: "c", Service: "FIREBASE", Collection: "nt-v2", StartedAt: mark},
+	}
+
+	got := journal.Find("FIREBASE", "nt")
+	if len(got) != 2 || got[0].ID != "a" || got[1].ID != "b" {
+		t.Errorf("Journal.Find sum was different: Got: %v", got)
+	}
+
+	if got := journal.Find("LOCAL", "nt"); len(got) != 0 {
+		t.Errorf("Journal.Find sum was different: Want: %v | Got: %v", 0, len(got))
+	}
+}
+
+func TestDecodeJournal(t *testing.T) {
+	tests := []struct {
+		testname string
+		value    string
+		expected int
+	}{
+		{
+			testname: "should decode journal properly",
+			value:    models.Journal{"a": {ID: "a", Title: "note.md"}}.ToString(),
+			expected: 1,
+		},
+		{
+			testname: "should return empty journal for invalid data",
+			value:    `not-a-json`,
+			expected: 0,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testname, func(t *testing.T) {
+			got := models.DecodeJournal(td.value)
+			if len(got) != td.expected {
+				t.Errorf("DecodeJournal sum was different: Want: %v | Got: %v", td.expected, len(got))
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Kinds of links between notes.
+const (
+	// WikiLink is a link like: [[note title]], [[folder/note|alias]] or [[note#heading]].
+	WikiLink = "wiki"
+
+	// MarkdownLink is a relative markdown link like: [text](../folder/note.md).
+	MarkdownLink = "markdown"
+)
+
+// Regexes of links, that are matched at each line of note's content.
+var (
+	wikiLinkRegex     = regexp.MustCompile(`!?\[\[([^\[\]|#]*)(#[^\[\]|]*)?(?:\|([^\[\]]*))?\]\]`)
+	markdownLinkRegex = regexp.MustCompile(`(!?)\[([^\[\]]*)\]\(\s*<?([^()\s<>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	schemeRegex       = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+)
+
+// Link is a reference from a note to another note.
+//
+//	Example:
+//
+// ╭─────────────────────────────────────────────╮
+// │ Kind: wiki                                  │
+// │ Source: journal/today.md                    │
+// │ Target: todo                                │
+// │ Text: my todo list                          │
+// │ Line: 3                                     │
+// │ Title: work/todo.md                         │
+// ╰─────────────────────────────────────────────╯
+type Link struct {
+	// Kind is the syntax of link, [WikiLink] or [MarkdownLink].
+	Kind string `json:"kind"`
+
+	// Source is the title of note, that link is written at.
+	Source string `json:"source"`
+
+	// Target is the referred note, as it's written at link.
+	// Without alias, heading and(url) escaping.
+	Target string `json:"target"`
+
+	// Text is the alias of wiki link, or the text of markdown link.
+	Text string `json:"text,omitempty"`
+
+	// Line is the line number of link, starting from 1.
+	Line int `json:"line"`
+
+	// Title is the title of note that link refers to.
+	// Empty title means link is dangling.
+	Title string `json:"title,omitempty"`
+}
+
+// IsDangling checks if link doesn't refer to an existing note.
+func (l *Link) IsDangling() bool {
+	return len(l.Title) == 0
+}
+
+// LinkRewrite is the rewritten body of note, which's links referred to a renamed node.
+type LinkRewrite struct {
+	// Title is the title of note, after renaming.
+	Title string `json:"title"`
+
+	// Body is the rewritten body of note.
+	Body string `json:"body"`
+
+	// Changes are the rewritten links of note.
+	Changes []LinkChange `json:"changes"`
+
+	// Hash is the hash of note's body, before rewriting.
+	Hash string `json:"hash"`
+
+	// UpdatedAt is the modification time of note, before rewriting.
+	// It's zero for notes moved by renaming, since they're re-created.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// LinkChange is a single rewritten link target.
+type LinkChange struct {
+	Line int    `json:"line"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// linkMatch is a link found at a line, with the position of its target.
+type linkMatch struct {
+	Link
+
+	// start and end are the bounds of target at line. For markdown
+	// links, they exclude the fragment and may contain escaped characters.
+	start, end int
+}
+
+// ParseLinks collects the links of given body, that could refer to other notes.
+// Embedded files(images), external urls, pure anchors and links inside
+// front matter or fenced code blocks are skipped.
+func ParseLinks(source, body string) []Link {
+	links := []Link{}
+	scanLinks(body, func(lineNo int, line string) string {
+		for _, m := range matchLinks(source, line, lineNo) {
+			links = append(links, m.Link)
+		}
+
+		return line
+	})
+
+	return links
+}
+
+// RewriteLinks replaces the targets of links at given body, by the result of [rewrite].
+// Links that [rewrite] returns false for, and the rest of body are kept as they are.
+func RewriteLinks(source, body string, rewrite func(link Link) (string, bool)) string {
+	return scanLinks(body, func(lineNo int, line string) string {
+		matches := matchLinks(source, line, lineNo)
+
+		targets := make([]string, len(matches))
+		for i, m := range matches {
+			target, ok := rewrite(m.Link)
+			if !ok {
+				targets[i] = line[m.start:m.end]
+				continue
+			}
+
+			if m.Kind == MarkdownLink {
+				target = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29").Replace(target)
+			}
+
+			targets[i] = target
+		}
+
+		// Replace from the end of line, to keep positions of previous matches valid.
+		for i := len(matches) - 1; i >= 0; i-- {
+			line = line[:matches[i].start] + targets[i] + line[matches[i].end:]
+		}
+
+		return line
+	})
+}
+
+// scanLinks calls [fn] for each line of body's content, that could contain links,
+// and joins the results of [fn] back. Front matter and fenced code blocks are kept as they are.
+func scanLinks(body string, fn func(lineNo int, line string) string) string {
+	_, content, ok := SplitFrontMatter(body)
+
+	// Keep line numbers relative to the whole body.
+	offset := 0
+	if ok {
+		offset = strings.Count(body[:len(body)-len(content)], "\n")
+	}
+
+	lines := strings.Split(content, "\n")
+	fenced := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			fenced = !fenced
+			continue
+		}
+
+		if !fenced {
+			lines[i] = fn(offset+i+1, line)
+		}
+	}
+
+	return body[:len(body)-len(content)] + strings.Join(lines, "\n")
+}
+
+// matchLinks finds the links of given line, in written order.
+func matchLinks(source, line string, lineNo int) []linkMatch {
+	found := map[int]linkMatch{}
+
+	for _, m := range wikiLinkRegex.FindAllStringSubmatchIndex(line, -1) {
+		target := strings.TrimSpace(line[m[2]:m[3]])
+		if line[m[0]] == '!' || len(target) == 0 {
+			continue
+		}
+
+		link := Link{Kind: WikiLink, Source: source, Target: target, Line: lineNo}
+		if m[6] >= 0 {
+			link.Text = line[m[6]:m[7]]
+		}
+
+		found[m[0]] = linkMatch{Link: link, start: m[2], end: m[3]}
+	}
+
+	for _, m := range markdownLinkRegex.FindAllStringSubmatchIndex(line, -1) {
+		target, end := line[m[6]:m[7]], m[7]
+		if m[3] > m[2] || strings.HasPrefix(target, "#") || schemeRegex.MatchString(target) {
+			continue
+		}
+
+		if j := strings.Index(target, "#"); j >= 0 {
+			target, end = target[:j], m[6]+j
+		}
+
+		if unescaped, err := url.PathUnescape(target); err == nil {
+			target = unescaped
+		}
+
+		link := Link{Kind: MarkdownLink, Source: source, Target: target, Text: line[m[4]:m[5]], Line: lineNo}
+		found[m[0]] = linkMatch{Link: link, start: m[6], end: end}
+	}
+
+	positions := []int{}
+	for p := range found {
+		positions = append(positions, p)
+	}
+	sort.Ints(positions)
+
+	matches := []linkMatch{}
+	for _, p := range positions {
+		matches = append(matches, found[p])
+	}
+
+	return matches
+}
@@ -0,0 +1,62 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/insolite-dev/nt/lib/models"
+)
+
+func TestParseLinks(t *testing.T) {
+	body := "---\nlink: \"[[not a link]]\"\n---\n" +
+		"See [[todo]] and [[work/plan#Goals|the plan]].\n" +
+		"![[diagram.png]] ![img](pic.png) [site](https://example.com) [top](#top)\n" +
+		"```\n[[in code]]\n```\n" +
+		"[Ideas](../ideas%20list.md#draft \"title\") and [[ ]]"
+
+	expected := []models.Link{
+		{Kind: models.WikiLink, Source: "journal/today.md", Target: "todo", Line: 4},
+		{Kind: models.WikiLink, Source: "journal/today.md", Target: "work/plan", Text: "the plan", Line: 4},
+		{Kind: models.MarkdownLink, Source: "journal/today.md", Target: "../ideas list.md", Text: "Ideas", Line: 9},
+	}
+
+	got := models.ParseLinks("journal/today.md", body)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("ParseLinks sum was different: Want: %v | Got: %v", expected, got)
+	}
+
+	dangling := models.Link{Target: "missing"}
+	if !dangling.IsDangling() {
+		t.Errorf("Link without title must be dangling")
+	}
+}
+
+func TestRewriteLinks(t *testing.T) {
+	body := "---\nlink: \"[[todo]]\"\n---\n" +
+		"See [[todo]], [[todo#Today|list]] and [todo](todo.md#today \"title\").\n" +
+		"```\n[[todo]]\n```\n" +
+		"Keep [[plan]] and ![[todo]]"
+
+	expected := "---\nlink: \"[[todo]]\"\n---\n" +
+		"See [[work/todo list]], [[work/todo list#Today|list]] and [todo](work/todo%20list#today \"title\").\n" +
+		"```\n[[todo]]\n```\n" +
+		"Keep [[plan]] and ![[todo]]"
+
+	got := models.RewriteLinks("journal.md", body, func(link models.Link) (string, bool) {
+		if link.Target != "todo" && link.Target != "todo.md" {
+			return "", false
+		}
+
+		return "work/todo list", true
+	})
+
+	if got != expected {
+		t.Errorf("RewriteLinks sum was different: Want: %v | Got: %v", expected, got)
+	}
+}
@@ -0,0 +1,72 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Mirror is a read-only local copy of a remote collection.
+// Used to view nodes of remote, while it's unreachable.
+//
+//	Example:
+//
+// ╭─────────────────────────────────────────────╮
+// │ Service: FIREBASE                           │
+// │ Collection: nt-notes                        │
+// │ Refreshed At: 2021-12-08 14:43:12 +0000 UTC │
+// │ Mark: 2021-12-08 14:40:02 +0000 UTC         │
+// │ Nodes: { "todo": {...}, "todo/a.md": {...} }│
+// ╰─────────────────────────────────────────────╯
+type Mirror struct {
+	// Service is the type of remote service, that mirror belongs to.
+	Service string `json:"service"`
+
+	// Collection is the name of remote base collection, that mirror belongs to.
+	Collection string `json:"collection"`
+
+	// RefreshedAt is the time of the last successful refresh.
+	// Mirrored data is stale as of this time.
+	RefreshedAt time.Time `json:"refreshed_at"`
+
+	// Mark is the latest modification time of mirrored nodes.
+	// Used to refresh mirror incrementally.
+	Mark time.Time `json:"mark"`
+
+	// ScannedAt is the time of the last full scan of collection.
+	// Incremental refreshes can't see removed nodes, so they're dropped only by full scans.
+	ScannedAt time.Time `json:"scanned_at"`
+
+	// Nodes are the mirrored nodes(including bodies), keyed by their trimmed titles.
+	Nodes map[string]Node `json:"nodes"`
+}
+
+// ToString converts mirror to a JSON string.
+func (m *Mirror) ToString() string {
+	jsonBytes, err := json.Marshal(m)
+	if err != nil {
+		return ""
+	}
+
+	return string(jsonBytes)
+}
+
+// DecodeMirror converts string(map) value to Mirror.
+// Invalid data results an empty mirror.
+func DecodeMirror(value string) Mirror {
+	m := Mirror{}
+	if err := json.Unmarshal([]byte(value), &m); err != nil {
+		return Mirror{Nodes: map[string]Node{}}
+	}
+
+	if m.Nodes == nil {
+		m.Nodes = map[string]Node{}
+	}
+
+	return m
+}
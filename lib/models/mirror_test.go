@@ -0,0 +1,51 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/insolite-dev/nt/lib/models"
+)
+
+func TestDecodeMirror(t *testing.T) {
+	mirror := models.Mirror{
+		Service:     "FIREBASE",
+		Collection:  "nt",
+		RefreshedAt: time.Date(2021, 12, 8, 14, 43, 12, 0, time.UTC),
+		Nodes: map[string]models.Node{
+			"note.md": {Type: models.FILE, Title: "note.md", Body: "hello"},
+		},
+	}
+
+	tests := []struct {
+		testname string
+		value    string
+		expected int
+	}{
+		{
+			testname: "should decode mirror properly",
+			value:    mirror.ToString(),
+			expected: 1,
+		},
+		{
+			testname: "should return empty mirror for invalid data",
+			value:    `not-a-json`,
+			expected: 0,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testname, func(t *testing.T) {
+			got := models.DecodeMirror(td.value)
+			if len(got.Nodes) != td.expected {
+				t.Errorf("DecodeMirror sum was different: Want: %v | Got: %v", td.expected, len(got.Nodes))
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"os"
 	"strings"
+	"time"
 )
 
 var (
@@ -42,6 +43,24 @@ type Node struct {
 	// A field representation of [Note]'s [Body].
 	Body string `json:"body,omitempty"`
 
+	// A field representation of [Note]'s [Hash].
+	Hash string `json:"hash,omitempty"`
+
+	// UpdatedAt is the last modification time of "current" node.
+	// Used as a high-water mark for incremental fetching.
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// CreatedAt is the creation time of "current" node.
+	CreatedAt time.Time `json:"created_at"`
+
+	// Size is the byte size of [Body], for file nodes.
+	Size int64 `json:"size,omitempty"`
+
+	// UpdatedBy is the user and device, that made the last modification of node.
+	// Formatted as "user@device". Local files don't record it, so it's the owner
+	// of file for local nodes(see [pkg.FileOwner]).
+	UpdatedBy string `json:"updated_by,omitempty"`
+
 	// Pretty is Title but powered with ascii emojis.
 	// Shouldn't used as a production field.
 	Pretty []string `json:"pretty,omitempty"`
@@ -117,7 +136,15 @@ func (n *Node) ToNote() Note {
 		title = title[:len(title)-1]
 	}
 
-	return Note{Title: title, Path: n.Path, Body: n.Body}
+	return Note{
+		Title:     title,
+		Path:      n.Path,
+		Body:      n.Body,
+		Hash:      n.Hash,
+		CreatedAt: n.CreatedAt,
+		Size:      n.Size,
+		UpdatedBy: n.UpdatedBy,
+	}
 }
 
 // ToFile converts [Node] object to [Folder].
@@ -8,6 +8,8 @@ package models
 
 import (
 	"encoding/json"
+	"strings"
+	"time"
 )
 
 // Note is the main note model of application.
@@ -18,11 +20,29 @@ import (
 // │ Title: new_note.txt                         │
 // │ Path: /User/random-user/nt/new_note.txt  │
 // │ Body: ... Note content here ...             │
+// │ Hash: 2cf24dba5fb0a30e26e83b2ac5b9e29e...   │
 // ╰─────────────────────────────────────────────╯
 type Note struct {
 	Title string            `json:"title"`
 	Path  map[string]string `json:"path"`
 	Body  string            `json:"body"`
+
+	// Hash is the content hash(SHA-256) of [Body].
+	Hash string `json:"hash,omitempty"`
+
+	// UpdatedAt is the last update time of note, at the moment of reading.
+	// Remote services use it as a precondition of editing, so it's never stored.
+	UpdatedAt time.Time `json:"-"`
+
+	// Metadata of note, at the moment of reading. Services generate
+	// them on their own while writing, so they're never stored from note.
+	CreatedAt time.Time `json:"-" mapstructure:"-"`
+	Size      int64     `json:"-" mapstructure:"size"`
+	UpdatedBy string    `json:"-" mapstructure:"updated_by"`
+
+	// Meta is the parsed front matter of [Body], at the moment of reading.
+	// It's a part of body, so it's never stored separately.
+	Meta *FrontMatter `json:"-" mapstructure:"-"`
 }
 
 // GetPath returns exact path of provided service.
@@ -43,5 +63,52 @@ func (s *Note) ToJSON() map[string]interface{} {
 
 // ToNode converts [Note] model to [Node] model.
 func (n *Note) ToNode() Node {
-	return Node{Type: FILE, Title: n.Title, Path: n.Path, Body: n.Body}
+	return Node{
+		Type:      FILE,
+		Title:     n.Title,
+		Path:      n.Path,
+		Body:      n.Body,
+		Hash:      n.Hash,
+		CreatedAt: n.CreatedAt,
+		Size:      n.Size,
+		UpdatedBy: n.UpdatedBy,
+	}
+}
+
+// Append adds [text] to the end of note's body, as a new line.
+func (n *Note) Append(text string) {
+	if len(n.Body) > 0 && !strings.HasSuffix(n.Body, "\n") {
+		n.Body += "\n"
+	}
+
+	n.Body += withNewline(text)
+}
+
+// Prepend adds [text] to the start of note's content, as a new line.
+// Front matter of note is kept at the top of body.
+func (n *Note) Prepend(text string) {
+	_, content, _ := SplitFrontMatter(n.Body)
+	n.Body = n.Body[:len(n.Body)-len(content)] + withNewline(text) + content
+}
+
+// CaptureEntry formats [text] as a bullet entry, timestamped by [now].
+// Following lines of multiline text are indented under the bullet.
+//
+//	Example: "- 2026-10-19 15:04 call the vendor"
+func CaptureEntry(text string, now time.Time) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i := 1; i < len(lines); i++ {
+		lines[i] = "  " + lines[i]
+	}
+
+	return "- " + now.Format("2006-01-02 15:04") + " " + strings.Join(lines, "\n")
+}
+
+// withNewline ensures that [text] ends with a new line.
+func withNewline(text string) string {
+	if strings.HasSuffix(text, "\n") {
+		return text
+	}
+
+	return text + "\n"
 }
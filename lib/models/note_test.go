@@ -8,6 +8,7 @@ package models_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/insolite-dev/nt/lib/models"
 	"github.com/insolite-dev/nt/lib/services"
@@ -65,3 +66,57 @@ func TestNoteToNode(t *testing.T) {
 		}
 	}
 }
+
+func TestAppendAndPrepend(t *testing.T) {
+	tests := []struct {
+		testName string
+		body     string
+		text     string
+		prepend  bool
+		expected string
+	}{
+		{testName: "should append to empty note", body: "", text: "first", expected: "first\n"},
+		{testName: "should append as a new line", body: "# todo\n- a", text: "- b\n", expected: "# todo\n- a\n- b\n"},
+		{testName: "should prepend to empty note", body: "", text: "first", prepend: true, expected: "first\n"},
+		{
+			testName: "should prepend after front matter",
+			body:     "---\ntags: [inbox]\n---\n- a\n",
+			text:     "- b",
+			prepend:  true,
+			expected: "---\ntags: [inbox]\n---\n- b\n- a\n",
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			note := models.Note{Title: "inbox.md", Body: td.body}
+			if td.prepend {
+				note.Prepend(td.text)
+			} else {
+				note.Append(td.text)
+			}
+
+			if note.Body != td.expected {
+				t.Errorf("Body was different: Want: %q | Got: %q", td.expected, note.Body)
+			}
+		})
+	}
+}
+
+func TestCaptureEntry(t *testing.T) {
+	now := time.Date(2026, 10, 19, 15, 4, 0, 0, time.Local)
+
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{text: "call the vendor", expected: "- 2026-10-19 15:04 call the vendor"},
+		{text: "pods:\nweb-1 Running\n", expected: "- 2026-10-19 15:04 pods:\n  web-1 Running"},
+	}
+
+	for _, td := range tests {
+		if got := models.CaptureEntry(td.text, now); got != td.expected {
+			t.Errorf("CaptureEntry sum was different: Want: %q | Got: %q", td.expected, got)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Kinds of operations, that could be queued while remote is unreachable.
+const (
+	CreateOperation = "create"
+	EditOperation   = "edit"
+	RemoveOperation = "remove"
+	RenameOperation = "rename"
+	MkdirOperation  = "mkdir"
+)
+
+// Operation is a remote operation, recorded while remote service
+// was unreachable, to be replayed on the next successful connection.
+//
+//	Example:
+//
+// ╭─────────────────────────────────────────────╮
+// │ ID: 1a2b3c4d                                │
+// │ Kind: rename                                │
+// │ Service: FIREBASE                           │
+// │ Collection: nt-notes                        │
+// │ Title: todo/today.md                        │
+// │ New Title: todo/tomorrow.md                 │
+// │ Queued At: 2021-12-08 14:43:12 +0000 UTC    │
+// ╰─────────────────────────────────────────────╯
+type Operation struct {
+	// ID is the unique key of operation.
+	ID string `json:"id"`
+
+	// Kind is the type of operation. See: [CreateOperation], [EditOperation] ...
+	Kind string `json:"kind"`
+
+	// Service is the type of remote service, that operation belongs to.
+	Service string `json:"service"`
+
+	// Collection is the name of remote base collection of operation.
+	Collection string `json:"collection"`
+
+	// Type is the type of node, that operation applies to.
+	Type NodeType `json:"typ,omitempty"`
+
+	// Title is the title of node, that operation applies to.
+	Title string `json:"title"`
+
+	// NewTitle is the new title of node, for rename operations.
+	NewTitle string `json:"new_title,omitempty"`
+
+	// Body is the new body of note, for create and edit operations.
+	Body string `json:"body,omitempty"`
+
+	// BaseHash is the content hash of remote note, that operation was based on.
+	// Used to detect changes of remote note, before replaying the operation.
+	// Empty for folders, and for notes that didn't exist when operation was queued.
+	BaseHash string `json:"base_hash,omitempty"`
+
+	// BaseTime is the last update time of remote note, that operation was based on.
+	BaseTime time.Time `json:"base_time,omitempty"`
+
+	// QueuedAt is the time when operation was recorded.
+	QueuedAt time.Time `json:"queued_at"`
+
+	// Attempts is the count of failed replays of operation.
+	Attempts int `json:"attempts,omitempty"`
+
+	// LastError is the error of the last failed replay.
+	LastError string `json:"last_error,omitempty"`
+
+	// Conflict represents whether the last replay was failed because of a conflict.
+	// Conflicted operations aren't replayed automatically.
+	Conflict bool `json:"conflict,omitempty"`
+}
+
+// Queue is a list of operations, in order of recording.
+type Queue []Operation
+
+// Find returns the operations of provided [service] and [collection].
+func (q Queue) Find(service, collection string) Queue {
+	ops := Queue{}
+	for _, op := range q {
+		if op.Service == service && op.Collection == collection {
+			ops = append(ops, op)
+		}
+	}
+
+	return ops
+}
+
+// Drop returns a copy of queue, without the operation of provided [id].
+func (q Queue) Drop(id string) Queue {
+	ops := Queue{}
+	for _, op := range q {
+		if op.ID != id {
+			ops = append(ops, op)
+		}
+	}
+
+	return ops
+}
+
+// ToString converts queue to a formatted JSON string.
+func (q Queue) ToString() string {
+	jsonBytes, err := json.MarshalIndent(q, "", "  ")
+	if err != nil {
+		return ""
+	}
+
+	return string(jsonBytes)
+}
+
+// DecodeQueue converts string(list) value to Queue.
+// Invalid data results an empty queue.
+func DecodeQueue(value string) Queue {
+	q := Queue{}
+	if err := json.Unmarshal([]byte(value), &q); err != nil {
+		return Queue{}
+	}
+
+	return q
+}
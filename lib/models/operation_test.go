@@ -0,0 +1,37 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models_test
+
+import (
+	"testing"
+
+	"github.com/insolite-dev/nt/lib/models"
+)
+
+func TestQueue(t *testing.T) {
+	queue := models.Queue{
+		{ID: "a", Service: "FIREBASE", Collection: "nt"},
+		{ID: "b", Service: "FIREBASE", Collection: "nt-v2"},
+		{ID: "c", Service: "FIREBASE", Collection: "nt"},
+	}
+
+	if got := queue.Find("FIREBASE", "nt"); len(got) != 2 || got[0].ID != "a" || got[1].ID != "c" {
+		t.Errorf("Queue.Find sum was different: Got: %v", got)
+	}
+
+	if got := queue.Drop("a"); len(got) != 2 || got[0].ID != "b" {
+		t.Errorf("Queue.Drop sum was different: Got: %v", got)
+	}
+
+	if got := models.DecodeQueue(queue.ToString()); len(got) != 3 {
+		t.Errorf("DecodeQueue sum was different: Want: %v | Got: %v", 3, len(got))
+	}
+
+	if got := models.DecodeQueue(`not-a-json`); len(got) != 0 {
+		t.Errorf("DecodeQueue sum was different: Want: %v | Got: %v", 0, len(got))
+	}
+}
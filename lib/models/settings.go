@@ -8,6 +8,7 @@ package models
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 )
@@ -18,12 +19,37 @@ const (
 	SettingsName     = ".settings.json"
 	DefaultEditor    = "vi"
 	DefaultLocalPath = "nt"
+	CheckpointsName  = ".checkpoints.json"
+	EditsName        = ".edits"
+	JournalName      = "journal.json"
+	QueueName        = ".queue.json"
+	MirrorName       = ".mirror.json"
+	SyncStatusName   = ".sync.json"
+	SyncLogName      = ".sync.log"
+	IgnoreFileName   = ".ntignore"
+)
+
+// Available document layouts of firebase collection.
+const (
+	// NestedLayout stores each folder's nodes in "sub" collection of folder document.
+	NestedLayout = "nested"
+
+	// FlatLayout stores each node as a top-level document of collection,
+	// keyed by the encoded path of node.
+	FlatLayout = "flat"
 )
 
 // NotyaIgnoreFiles are those files that shouldn't
-// be represented as note files.
+// be represented as note files. They're built-in ignore patterns,
+// user defined ones come from [Settings.Ignore] and [IgnoreFileName] files.
 var NotyaIgnoreFiles []string = []string{
 	SettingsName,
+	CheckpointsName,
+	EditsName,
+	QueueName,
+	MirrorName,
+	SyncStatusName,
+	SyncLogName,
 	".DS_Store", // Darwin related.
 	".git",
 }
@@ -78,6 +104,65 @@ type Settings struct {
 	// The concrete collection of nodes.
 	// Does same job as [NotesPath] but has to take just name of collection.
 	FirebaseCollection string `json:"fire_collection,omitempty" mapstructure:"fire_collection,omitempty" survey:"fire_collection"`
+
+	// The document layout of firebase collection.
+	// Could be:
+	//   - nested (default)
+	//   - flat
+	FirebaseLayout string `json:"fire_layout,omitempty" mapstructure:"fire_layout,omitempty"`
+
+	// The count of retries of failed firebase requests, that failed by a transient error.
+	// Zero means default([DefaultRetries]), a negative value disables retrying.
+	FirebaseRetries int `json:"fire_retries,omitempty" mapstructure:"fire_retries,omitempty"`
+
+	// The initial delay(in milliseconds) between retries, which doubles after each retry.
+	// Zero means default([DefaultBackoff]).
+	FirebaseBackoff int `json:"fire_backoff,omitempty" mapstructure:"fire_backoff,omitempty"`
+
+	// The maximum delay(in milliseconds) between retries.
+	// Zero means default([DefaultMaxBackoff]).
+	FirebaseMaxBackoff int `json:"fire_max_backoff,omitempty" mapstructure:"fire_max_backoff,omitempty"`
+
+	// Additional gitignore-style patterns of nodes, that should be ignored by all services.
+	// Applied in addition to [NotyaIgnoreFiles] and [IgnoreFileName] files of folders.
+	// Like: ["*.tmp", "node_modules/", "*.swp"].
+	Ignore []string `json:"ignore,omitempty" mapstructure:"ignore,omitempty"`
+
+	// Saved queries of nodes, by their names. They could be run by name,
+	// or referred from other queries as @name.
+	// Like: {"oncall": "tag:oncall AND path:runbooks/"}.
+	Queries map[string]string `json:"queries,omitempty" mapstructure:"queries,omitempty"`
+
+	// The folder of daily journal notes, relative to the root of notes.
+	// Empty means default([DefaultJournalFolder]).
+	JournalFolder string `json:"journal_folder,omitempty" mapstructure:"journal_folder,omitempty"`
+
+	// The Go time layout of daily journal notes' titles, relative to [JournalFolder].
+	// Empty means default([DefaultJournalFormat]), like: "2006/01/2006-01-02.md".
+	JournalFormat string `json:"journal_format,omitempty" mapstructure:"journal_format,omitempty"`
+
+	// The name of template(from [TemplatesFolder]) that new daily journal notes are created from.
+	// Empty means notes are created with a heading of their date.
+	JournalTemplate string `json:"journal_template,omitempty" mapstructure:"journal_template,omitempty"`
+}
+
+// Default limits of retrying firebase requests.
+const (
+	DefaultRetries    = 4
+	DefaultBackoff    = 200 * time.Millisecond
+	DefaultMaxBackoff = 5 * time.Second
+)
+
+// RetryPolicy is the limits of retrying requests, failed by a transient error.
+type RetryPolicy struct {
+	// Retries is the maximum count of retries, after the first attempt.
+	Retries int
+
+	// Backoff is the initial delay between retries.
+	Backoff time.Duration
+
+	// MaxBackoff is the maximum delay between retries.
+	MaxBackoff time.Duration
 }
 
 // CopyWith updates pointed settings with a new data.
@@ -170,6 +255,40 @@ func (s *Settings) FirePath() string {
 	return DefaultAppName
 }
 
+// FireLayout returns valid document layout of firebase collection.
+func (s *Settings) FireLayout() string {
+	if s.FirebaseLayout == FlatLayout {
+		return FlatLayout
+	}
+
+	return NestedLayout
+}
+
+// FireRetryPolicy returns valid retry policy of firebase requests.
+func (s *Settings) FireRetryPolicy() RetryPolicy {
+	policy := RetryPolicy{
+		Retries:    s.FirebaseRetries,
+		Backoff:    time.Duration(s.FirebaseBackoff) * time.Millisecond,
+		MaxBackoff: time.Duration(s.FirebaseMaxBackoff) * time.Millisecond,
+	}
+
+	if policy.Retries == 0 {
+		policy.Retries = DefaultRetries
+	} else if policy.Retries < 0 {
+		policy.Retries = 0
+	}
+
+	if policy.Backoff <= 0 {
+		policy.Backoff = DefaultBackoff
+	}
+
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = DefaultMaxBackoff
+	}
+
+	return policy
+}
+
 // IsValid checks validness of settings structure.
 func (s *Settings) IsValid() bool {
 	return len(s.Name) > 0 && len(s.Editor) > 0 && len(s.NotesPath) > 0
@@ -8,6 +8,7 @@ package models_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/insolite-dev/nt/lib/models"
 )
@@ -147,6 +148,66 @@ func TestFirePath(t *testing.T) {
 	}
 }
 
+func TestFireLayout(t *testing.T) {
+	tests := []struct {
+		model    models.Settings
+		expected string
+	}{
+		{
+			model:    models.Settings{},
+			expected: models.NestedLayout,
+		},
+		{
+			model:    models.Settings{FirebaseLayout: "unknown"},
+			expected: models.NestedLayout,
+		},
+		{
+			model:    models.Settings{FirebaseLayout: models.FlatLayout},
+			expected: models.FlatLayout,
+		},
+	}
+
+	for _, td := range tests {
+		got := td.model.FireLayout()
+
+		if got != td.expected {
+			t.Errorf("FireLayout's sum was different: Want: %v | Got: %v", got, td.expected)
+		}
+	}
+}
+
+func TestFireRetryPolicy(t *testing.T) {
+	tests := []struct {
+		model    models.Settings
+		expected models.RetryPolicy
+	}{
+		{
+			model: models.Settings{},
+			expected: models.RetryPolicy{
+				Retries:    models.DefaultRetries,
+				Backoff:    models.DefaultBackoff,
+				MaxBackoff: models.DefaultMaxBackoff,
+			},
+		},
+		{
+			model: models.Settings{FirebaseRetries: -1, FirebaseBackoff: 50, FirebaseMaxBackoff: 1000},
+			expected: models.RetryPolicy{
+				Retries:    0,
+				Backoff:    50 * time.Millisecond,
+				MaxBackoff: time.Second,
+			},
+		},
+	}
+
+	for _, td := range tests {
+		got := td.model.FireRetryPolicy()
+
+		if got != td.expected {
+			t.Errorf("FireRetryPolicy's sum was different: Want: %v | Got: %v", td.expected, got)
+		}
+	}
+}
+
 func TestIsValid(t *testing.T) {
 	tests := []struct {
 		testname string
@@ -0,0 +1,82 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models
+
+// States of node, that's compared between local and remote services.
+const (
+	// LocalOnlyState is a node that exists only at local service.
+	LocalOnlyState = "local-only"
+
+	// RemoteOnlyState is a node that exists only at remote service.
+	RemoteOnlyState = "remote-only"
+
+	// ModifiedState is a note which's content differs between services.
+	ModifiedState = "modified"
+
+	// IdenticalState is a node that's same at both services.
+	IdenticalState = "identical"
+)
+
+// Sides of modification, for the [ModifiedState] nodes.
+// Side is decided by the hashes of nodes at their last sync,
+// so it's empty if node has never been synced with remote.
+const (
+	LocalSide  = "local"
+	RemoteSide = "remote"
+	BothSides  = "both"
+)
+
+// NodeStatus is the compared state of a single node.
+//
+//	Example:
+//
+// ╭──────────────────────────────────────╮
+// │ Title: todo/today.md                 │
+// │ Type: FILE                           │
+// │ State: modified                      │
+// │ Side: remote                         │
+// ╰──────────────────────────────────────╯
+type NodeStatus struct {
+	// Title is the full path title of node.
+	Title string `json:"title"`
+
+	// Type is the type of node.
+	Type NodeType `json:"typ"`
+
+	// State is the compared state of node, one of [LocalOnlyState],
+	// [RemoteOnlyState], [ModifiedState] or [IdenticalState].
+	State string `json:"state"`
+
+	// Side is the service(s) that modified the node since its last sync.
+	Side string `json:"side,omitempty"`
+}
+
+// StatusReport is the result of comparing local service with a remote.
+type StatusReport struct {
+	// Remote is the type of compared remote service.
+	Remote string `json:"remote"`
+
+	// Nodes are the compared states of nodes, sorted by title.
+	Nodes []NodeStatus `json:"nodes"`
+}
+
+// Filter returns the node statuses at given [state].
+func (r *StatusReport) Filter(state string) []NodeStatus {
+	res := []NodeStatus{}
+	for _, n := range r.Nodes {
+		if n.State == state {
+			res = append(res, n)
+		}
+	}
+
+	return res
+}
+
+// InSync checks if all nodes are identical at both services.
+func (r *StatusReport) InSync() bool {
+	return len(r.Filter(IdenticalState)) == len(r.Nodes)
+}
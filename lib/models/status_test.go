@@ -0,0 +1,65 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models_test
+
+import (
+	"testing"
+
+	"github.com/insolite-dev/nt/lib/models"
+)
+
+func TestStatusReport(t *testing.T) {
+	tests := []struct {
+		testname  string
+		report    models.StatusReport
+		modified  int
+		identical int
+		inSync    bool
+	}{
+		{
+			testname: "should be in sync for an empty report",
+			report:   models.StatusReport{},
+			inSync:   true,
+		},
+		{
+			testname: "should be in sync if all nodes are identical",
+			report: models.StatusReport{Nodes: []models.NodeStatus{
+				{Title: "todo/", State: models.IdenticalState},
+				{Title: "todo/today.md", State: models.IdenticalState},
+			}},
+			identical: 2,
+			inSync:    true,
+		},
+		{
+			testname: "should be out of sync if any node differs",
+			report: models.StatusReport{Nodes: []models.NodeStatus{
+				{Title: "todo/", State: models.IdenticalState},
+				{Title: "todo/today.md", State: models.ModifiedState, Side: models.RemoteSide},
+				{Title: "ideas.md", State: models.LocalOnlyState},
+			}},
+			modified:  1,
+			identical: 1,
+			inSync:    false,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testname, func(t *testing.T) {
+			if got := len(td.report.Filter(models.ModifiedState)); got != td.modified {
+				t.Errorf("StatusReport.Filter(modified) sum was different: Want: %v | Got: %v", td.modified, got)
+			}
+
+			if got := len(td.report.Filter(models.IdenticalState)); got != td.identical {
+				t.Errorf("StatusReport.Filter(identical) sum was different: Want: %v | Got: %v", td.identical, got)
+			}
+
+			if got := td.report.InSync(); got != td.inSync {
+				t.Errorf("StatusReport.InSync sum was different: Want: %v | Got: %v", td.inSync, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// SyncStatus is the state of sync daemon, that's shared with
+// other nt processes via status file.
+//
+//	Example:
+//
+// ╭───────────────────────────────────────────────╮
+// │ PID: 4242                                     │
+// │ Remote: FIREBASE                              │
+// │ Started At: 2021-12-08 14:43:12 +0000 UTC     │
+// │ Last Push: 2021-12-08 14:50:02 +0000 UTC      │
+// │ Last Fetch: 2021-12-08 14:51:12 +0000 UTC     │
+// │ Pushed: 3                                     │
+// │ Fetched: 1                                    │
+// ╰───────────────────────────────────────────────╯
+type SyncStatus struct {
+	// PID is the process id of sync daemon.
+	PID int `json:"pid"`
+
+	// Remote is the type of remote service, that local service is synced with.
+	Remote string `json:"remote"`
+
+	// StartedAt is the start time of sync daemon.
+	StartedAt time.Time `json:"started_at"`
+
+	// StoppedAt is the stop time of sync daemon.
+	// Zero value means daemon is still running (or was killed).
+	StoppedAt time.Time `json:"stopped_at,omitempty"`
+
+	// LastPush is the time of the last push to remote.
+	LastPush time.Time `json:"last_push,omitempty"`
+
+	// LastFetch is the time of the last fetch from remote.
+	LastFetch time.Time `json:"last_fetch,omitempty"`
+
+	// Pushed is the total count of pushed nodes, since daemon was started.
+	Pushed int `json:"pushed"`
+
+	// Fetched is the total count of fetched nodes, since daemon was started.
+	Fetched int `json:"fetched"`
+
+	// LastError is the last error of syncing.
+	LastError string `json:"last_error,omitempty"`
+
+	// Interval is the period of fetching remote changes.
+	Interval time.Duration `json:"interval,omitempty"`
+
+	// AliveAt is the last time, daemon has written its status.
+	// Daemon writes its status at least once per [Interval].
+	AliveAt time.Time `json:"alive_at,omitempty"`
+}
+
+// IsRunning checks if the daemon of status is still running at [now].
+// Status of a killed daemon isn't updated, so it's considered stopped
+// after two intervals of silence.
+func (s *SyncStatus) IsRunning(now time.Time) bool {
+	if s.PID == 0 || !s.StoppedAt.IsZero() || s.Interval <= 0 {
+		return false
+	}
+
+	return now.Sub(s.AliveAt) < 2*s.Interval
+}
+
+// ToString converts sync status to a formatted JSON string.
+func (s *SyncStatus) ToString() string {
+	jsonBytes, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return ""
+	}
+
+	return string(jsonBytes)
+}
+
+// DecodeSyncStatus converts string(map) value to SyncStatus.
+// Invalid data results a zero-valued status.
+func DecodeSyncStatus(value string) SyncStatus {
+	var s SyncStatus
+	if err := json.Unmarshal([]byte(value), &s); err != nil {
+		return SyncStatus{}
+	}
+
+	return s
+}
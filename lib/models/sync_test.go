@@ -0,0 +1,89 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/insolite-dev/nt/lib/models"
+)
+
+func TestDecodeSyncStatus(t *testing.T) {
+	status := models.SyncStatus{
+		PID:       4242,
+		Remote:    "FIREBASE",
+		StartedAt: time.Date(2021, 12, 8, 14, 43, 12, 0, time.UTC),
+		Pushed:    3,
+	}
+
+	tests := []struct {
+		testname string
+		value    string
+		expected models.SyncStatus
+	}{
+		{
+			testname: "should decode sync status properly",
+			value:    status.ToString(),
+			expected: status,
+		},
+		{
+			testname: "should return zero status for invalid data",
+			value:    `not-a-json`,
+			expected: models.SyncStatus{},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testname, func(t *testing.T) {
+			got := models.DecodeSyncStatus(td.value)
+			if got != td.expected {
+				t.Errorf("DecodeSyncStatus sum was different: Want: %v | Got: %v", td.expected, got)
+			}
+		})
+	}
+}
+
+func TestSyncStatusIsRunning(t *testing.T) {
+	now := time.Date(2021, 12, 8, 14, 43, 12, 0, time.UTC)
+
+	tests := []struct {
+		testname string
+		status   models.SyncStatus
+		expected bool
+	}{
+		{
+			testname: "should be running if status is fresh",
+			status:   models.SyncStatus{PID: 4242, Interval: time.Minute, AliveAt: now.Add(-time.Minute)},
+			expected: true,
+		},
+		{
+			testname: "should be stopped if status is stale",
+			status:   models.SyncStatus{PID: 4242, Interval: time.Minute, AliveAt: now.Add(-3 * time.Minute)},
+			expected: false,
+		},
+		{
+			testname: "should be stopped if daemon has stopped",
+			status:   models.SyncStatus{PID: 4242, Interval: time.Minute, AliveAt: now, StoppedAt: now},
+			expected: false,
+		},
+		{
+			testname: "should be stopped if status is written by older version",
+			status:   models.SyncStatus{PID: 4242},
+			expected: false,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testname, func(t *testing.T) {
+			got := td.status.IsRunning(now)
+			if got != td.expected {
+				t.Errorf("SyncStatus.IsRunning sum was different: Want: %v | Got: %v", td.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models
+
+import (
+	"regexp"
+	"strings"
+)
+
+// inlineTagRegex matches the inline tags of content, like: #todo or #work/project.
+// Tag must start at the beginning of line or after a space, to skip headings and anchors.
+var inlineTagRegex = regexp.MustCompile(`(?:^|[\s(])#([\p{L}\p{N}_/-]+)`)
+
+// NormalizeTag trims the spaces, the leading hash and the trailing slashes of tag.
+func NormalizeTag(tag string) string {
+	return strings.Trim(strings.TrimPrefix(strings.TrimSpace(tag), "#"), "/")
+}
+
+// InlineTags collects the inline tags of given body.
+// Front matter and fenced code blocks are skipped, and pure numbers(like #1) aren't tags.
+func InlineTags(body string) []string {
+	_, content, _ := SplitFrontMatter(body)
+
+	tags := []string{}
+	fenced := false
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			fenced = !fenced
+			continue
+		}
+
+		if fenced {
+			continue
+		}
+
+		for _, match := range inlineTagRegex.FindAllStringSubmatch(line, -1) {
+			tag := NormalizeTag(match[1])
+			if len(strings.Trim(tag, "0123456789")) == 0 {
+				continue
+			}
+
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// ParseTags collects all tags of given body, from its front matter and content.
+// Tags are case-insensitive, so duplicates are removed by keeping the first form of tag.
+func ParseTags(body string) []string {
+	var tags []string
+	if meta, err := ParseFrontMatter(body); err == nil {
+		tags = append(tags, meta.Tags...)
+	}
+
+	tags = append(tags, InlineTags(body)...)
+
+	res := []string{}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = NormalizeTag(tag)
+		if len(tag) == 0 || seen[strings.ToLower(tag)] {
+			continue
+		}
+
+		seen[strings.ToLower(tag)] = true
+		res = append(res, tag)
+	}
+
+	return res
+}
+
+// HasTag checks if [tags] contains the given [tag], or one of its nested tags.
+// So, "work" tag is matched by "work" and "work/project" tags.
+func HasTag(tags []string, tag string) bool {
+	tag = strings.ToLower(NormalizeTag(tag))
+
+	for _, t := range tags {
+		t = strings.ToLower(t)
+		if t == tag || strings.HasPrefix(t, tag+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Tags returns all tags of node. Folders haven't got tags.
+func (n *Node) Tags() []string {
+	if !n.IsFile() {
+		return nil
+	}
+
+	return ParseTags(n.Body)
+}
@@ -0,0 +1,77 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/insolite-dev/nt/lib/models"
+)
+
+func TestInlineTags(t *testing.T) {
+	tests := []struct {
+		body     string
+		expected []string
+	}{
+		{body: "no tags here", expected: []string{}},
+		{body: "# Heading\n#todo buy milk (#shopping) #work/project/", expected: []string{"todo", "shopping", "work/project"}},
+		{body: "issue #42 and a url.com/#anchor", expected: []string{}},
+		{body: "---\ntags: [meta]\n---\n```\n#not-a-tag\n```\n#real", expected: []string{"real"}},
+	}
+
+	for _, td := range tests {
+		got := models.InlineTags(td.body)
+		if !reflect.DeepEqual(got, td.expected) {
+			t.Errorf("InlineTags of %q was different: Want: %v | Got: %v", td.body, td.expected, got)
+		}
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		body     string
+		expected []string
+	}{
+		{body: "plain note", expected: []string{}},
+		{body: "---\ntags: [Work, \"#ideas\"]\n---\n#work and #later", expected: []string{"Work", "ideas", "later"}},
+		{body: "---\n- invalid\n---\n#inline", expected: []string{"inline"}},
+	}
+
+	for _, td := range tests {
+		got := models.ParseTags(td.body)
+		if !reflect.DeepEqual(got, td.expected) {
+			t.Errorf("ParseTags of %q was different: Want: %v | Got: %v", td.body, td.expected, got)
+		}
+	}
+
+	folder := models.Node{Type: models.FOLDER, Title: "work/", Body: "#work"}
+	if tags := folder.Tags(); tags != nil {
+		t.Errorf("Tags of folder must be nil, Got: %v", tags)
+	}
+}
+
+func TestHasTag(t *testing.T) {
+	tests := []struct {
+		tags     []string
+		tag      string
+		expected bool
+	}{
+		{tags: []string{"work"}, tag: "work", expected: true},
+		{tags: []string{"Work"}, tag: "#work", expected: true},
+		{tags: []string{"work/project"}, tag: "work", expected: true},
+		{tags: []string{"workshop"}, tag: "work", expected: false},
+		{tags: []string{"work"}, tag: "work/project", expected: false},
+		{tags: nil, tag: "work", expected: false},
+	}
+
+	for _, td := range tests {
+		if got := models.HasTag(td.tags, td.tag); got != td.expected {
+			t.Errorf("HasTag(%v, %v) was different: Want: %v | Got: %v", td.tags, td.tag, td.expected, got)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models
+
+import (
+	"regexp"
+	"strings"
+)
+
+// TemplatesFolder is the folder of note templates, at the root of notes.
+// It's a regular folder of service, so templates are synced and listed like notes.
+const TemplatesFolder = ".templates"
+
+// NoteIgnoreFiles are the ignore patterns of commands, that treat nodes as
+// notes(like graph, query and link diagnosis). Unlike [NotyaIgnoreFiles],
+// templates are ignored too, since their links and metadata are placeholders.
+var NoteIgnoreFiles []string = append(append([]string{}, NotyaIgnoreFiles...), "/"+TemplatesFolder+"/")
+
+// Built-in variables of templates, that are filled without prompting.
+const (
+	DateVariable     = "date"
+	TimeVariable     = "time"
+	DateTimeVariable = "datetime"
+	TitleVariable    = "title"
+	PathVariable     = "path"
+	UserVariable     = "user"
+)
+
+// templateVariableRegex matches variables of template, like: {{title}} or {{project|nt}}.
+var templateVariableRegex = regexp.MustCompile(`\{\{\s*([a-zA-Z_][a-zA-Z0-9_-]*)\s*(?:\|([^{}]*))?\}\}`)
+
+// TemplateVariable is a variable of template, that's replaced by its value at instantiation.
+type TemplateVariable struct {
+	// Name is the name of variable, like "title" at {{title}}.
+	Name string `json:"name"`
+
+	// Default is the value of variable, written after "|" at {{project|nt}}.
+	Default string `json:"default,omitempty"`
+}
+
+// IsBuiltIn checks if variable is filled by nt, without prompting.
+func (v *TemplateVariable) IsBuiltIn() bool {
+	switch strings.ToLower(v.Name) {
+	case DateVariable, TimeVariable, DateTimeVariable, TitleVariable, PathVariable, UserVariable:
+		return true
+	}
+
+	return false
+}
+
+// TemplateVariables collects the unique variables of template's [body], in written order.
+// Default of the first occurrence of variable is used.
+func TemplateVariables(body string) []TemplateVariable {
+	variables := []TemplateVariable{}
+
+	seen := map[string]bool{}
+	for _, m := range templateVariableRegex.FindAllStringSubmatch(body, -1) {
+		name := strings.ToLower(m[1])
+		if seen[name] {
+			continue
+		}
+
+		seen[name] = true
+		variables = append(variables, TemplateVariable{Name: m[1], Default: strings.TrimSpace(m[2])})
+	}
+
+	return variables
+}
+
+// ExpandTemplate replaces the variables of template's [body] by their [values].
+// Variable names are case-insensitive, variables without value are replaced by their defaults.
+func ExpandTemplate(body string, values map[string]string) string {
+	lowered := map[string]string{}
+	for name, value := range values {
+		lowered[strings.ToLower(name)] = value
+	}
+
+	return templateVariableRegex.ReplaceAllStringFunc(body, func(match string) string {
+		m := templateVariableRegex.FindStringSubmatch(match)
+		if value, ok := lowered[strings.ToLower(m[1])]; ok {
+			return value
+		}
+
+		return strings.TrimSpace(m[2])
+	})
+}
@@ -0,0 +1,67 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package models_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/insolite-dev/nt/lib/models"
+)
+
+func TestTemplateVariables(t *testing.T) {
+	body := "# {{title}}\nDate: {{ date }}\nProject: {{project|nt}}\nOwner: {{Owner}} ({{project}})\nNot a {variable} or {{}}"
+
+	expected := []models.TemplateVariable{
+		{Name: "title"},
+		{Name: "date"},
+		{Name: "project", Default: "nt"},
+		{Name: "Owner"},
+	}
+
+	got := models.TemplateVariables(body)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("TemplateVariables sum was different: Want: %v | Got: %v", expected, got)
+	}
+
+	builtIn := []bool{true, true, false, false}
+	for i, v := range got {
+		if v.IsBuiltIn() != builtIn[i] {
+			t.Errorf("IsBuiltIn of %v was different: Want: %v | Got: %v", v.Name, builtIn[i], v.IsBuiltIn())
+		}
+	}
+}
+
+func TestExpandTemplate(t *testing.T) {
+	tests := []struct {
+		body     string
+		values   map[string]string
+		expected string
+	}{
+		{
+			body:     "# {{title}}\n{{ date }} by {{USER}}",
+			values:   map[string]string{"title": "todo", "date": "2026-10-19", "user": "anna"},
+			expected: "# todo\n2026-10-19 by anna",
+		},
+		{
+			body:     "Project: {{project|nt}}, owner: {{owner}}",
+			values:   map[string]string{},
+			expected: "Project: nt, owner: ",
+		},
+		{
+			body:     "Project: {{project|nt}}",
+			values:   map[string]string{"project": ""},
+			expected: "Project: ",
+		},
+	}
+
+	for _, td := range tests {
+		if got := models.ExpandTemplate(td.body, td.values); got != td.expected {
+			t.Errorf("ExpandTemplate sum was different: Want: %v | Got: %v", td.expected, got)
+		}
+	}
+}
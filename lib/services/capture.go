@@ -0,0 +1,65 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services
+
+import (
+	"github.com/insolite-dev/nt/assets"
+	"github.com/insolite-dev/nt/lib/models"
+)
+
+// addRetries is the count of re-tries of adding to a note, that was changed
+// by someone else after it was read. See [assets.EditConflict].
+const addRetries = 3
+
+// CreateNote creates the [note], with the missing folders of its title.
+// Folders are made one by one, since remote services require parent folders to exist.
+func CreateNote(service ServiceRepo, note models.Note) (*models.Note, error) {
+	if err := ensureParents(service, note.Title); err != nil {
+		return nil, err
+	}
+
+	return service.Create(note)
+}
+
+// AddToNote appends(or prepends) [text] to the body of note of [title], without overwriting it.
+// Note is created with the missing folders of its title, if it doesn't exist, so
+// quick captures could be collected at an inbox note, that's created on demand.
+//
+// Note is edited only if it wasn't changed after it was read, so concurrent captures
+// aren't lost. Adding is re-tried with the latest body of note, until [addRetries].
+func AddToNote(service ServiceRepo, title, text string, prepend bool) (*models.Note, error) {
+	for retry := 0; ; retry++ {
+		note, err := addToNote(service, title, text, prepend)
+		if err != assets.EditConflict || retry == addRetries {
+			return note, err
+		}
+	}
+}
+
+// addToNote makes a single attempt of [AddToNote].
+func addToNote(service ServiceRepo, title, text string, prepend bool) (*models.Note, error) {
+	if exists, _ := service.IsNodeExists(models.Node{Title: title}); !exists {
+		note := models.Note{Title: title}
+		note.Append(text)
+
+		return CreateNote(service, note)
+	}
+
+	note, err := service.View(models.Note{Title: title})
+	if err != nil {
+		return nil, err
+	}
+
+	if prepend {
+		note.Prepend(text)
+	} else {
+		note.Append(text)
+	}
+
+	// Viewed note keeps its modification time, to not overwrite concurrent changes.
+	return service.Edit(*note)
+}
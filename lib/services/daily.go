@@ -0,0 +1,67 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/insolite-dev/nt/assets"
+	"github.com/insolite-dev/nt/lib/models"
+)
+
+// ParseJournalDate parses the day of daily note, relative to [now].
+// Value could be a date like "2026-10-18", "today", "yesterday", "tomorrow" or a count of days like "-3".
+func ParseJournalDate(value string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch value = strings.ToLower(strings.TrimSpace(value)); value {
+	case "", "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	}
+
+	if days, err := strconv.Atoi(value); err == nil {
+		return today.AddDate(0, 0, days), nil
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", value, now.Location())
+	if err != nil {
+		return time.Time{}, assets.InvalidJournalDate
+	}
+
+	return date, nil
+}
+
+// DailyNotes collects the daily journal notes of [nodes], that belong to [since] or a later day.
+// Zero valued [since] collects all daily notes. Result is sorted by date, newest first.
+func DailyNotes(nodes []models.Node, settings models.Settings, since time.Time) []models.DailyNote {
+	notes := []models.DailyNote{}
+	for _, node := range nodes {
+		if !node.IsFile() {
+			continue
+		}
+
+		date, ok := settings.JournalDate(node.Title)
+		if !ok || date.Before(since) {
+			continue
+		}
+
+		notes = append(notes, models.DailyNote{Date: date, Node: node})
+	}
+
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].Date.After(notes[j].Date)
+	})
+
+	return notes
+}
@@ -0,0 +1,77 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/insolite-dev/nt/assets"
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/lib/services"
+)
+
+func TestParseJournalDate(t *testing.T) {
+	now := time.Date(2026, 10, 18, 15, 30, 0, 0, time.Local)
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.Local) }
+
+	tests := []struct {
+		value    string
+		expected time.Time
+		err      error
+	}{
+		{value: "", expected: day(18)},
+		{value: "Today", expected: day(18)},
+		{value: "yesterday", expected: day(17)},
+		{value: "tomorrow", expected: day(19)},
+		{value: "-3", expected: day(15)},
+		{value: "2026-10-01", expected: day(1)},
+		{value: "18.10.2026", err: assets.InvalidJournalDate},
+	}
+
+	for _, td := range tests {
+		got, err := services.ParseJournalDate(td.value, now)
+		if err != td.err || !got.Equal(td.expected) {
+			t.Errorf("ParseJournalDate(%v) sum is different, Want: %v, %v | Got: %v, %v", td.value, td.expected, td.err, got, err)
+		}
+	}
+}
+
+func TestDailyNotes(t *testing.T) {
+	nodes := []models.Node{
+		{Type: models.FOLDER, Title: "journal/2026/10/"},
+		{Type: models.FILE, Title: "journal/2026/10/2026-10-10.md"},
+		{Type: models.FILE, Title: "journal/2026/10/2026-10-18.md"},
+		{Type: models.FILE, Title: "journal/2026/10/2026-10-12.md"},
+		{Type: models.FILE, Title: "journal/ideas.md"},
+	}
+
+	tests := []struct {
+		since    time.Time
+		expected []string
+	}{
+		{
+			expected: []string{"journal/2026/10/2026-10-18.md", "journal/2026/10/2026-10-12.md", "journal/2026/10/2026-10-10.md"},
+		},
+		{
+			since:    time.Date(2026, 10, 12, 0, 0, 0, 0, time.Local),
+			expected: []string{"journal/2026/10/2026-10-18.md", "journal/2026/10/2026-10-12.md"},
+		},
+	}
+
+	for _, td := range tests {
+		got := []string{}
+		for _, note := range services.DailyNotes(nodes, models.Settings{}, td.since) {
+			got = append(got, note.Node.Title)
+		}
+
+		if !reflect.DeepEqual(got, td.expected) {
+			t.Errorf("DailyNotes(%v) sum is different, Want: %v | Got: %v", td.since, td.expected, got)
+		}
+	}
+}
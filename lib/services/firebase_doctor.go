@@ -0,0 +1,277 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"cloud.google.com/go/firestore"
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/pkg"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Doctor detects inconsistencies of firebase collection:
+//   - documents left in "sub" collections of removed folders.
+//   - documents which's stored path disagrees with their real location.
+//   - documents that couldn't be decoded as [models.Node].
+//   - documents without [updated_at] field, written by older versions of nt.
+//
+// If [fix] is true, orphans are removed, stored paths are updated and missing
+// modification times are backfilled.
+// Undecodable documents are only reported, they must be repaired manually.
+func (s *FirebaseService) Doctor(fix bool) (*models.DoctorReport, error) {
+	report := &models.DoctorReport{}
+
+	if s.IsFlat() {
+		return report, s.diagnoseFlat(report, fix)
+	}
+
+	collection := s.NotyaCollection()
+	return report, s.diagnoseNested(&collection, report, fix)
+}
+
+// diagnoseNested is the nested layout implementation of [Doctor].
+// Walks through the documents of [path] collection, recursively.
+func (s *FirebaseService) diagnoseNested(path *firestore.CollectionRef, report *models.DoctorReport, fix bool) error {
+	// Unlike [Documents], [DocumentRefs] includes the missing documents,
+	// that still have sub collections.
+	refs, err := s.listRefs(path)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		if pkg.IsIgnorable(ref.ID, models.NotyaIgnoreFiles) {
+			continue
+		}
+
+		location := s.docLocation(ref)
+
+		snap, err := s.fetchDoc(ref)
+		if status.Code(err) == codes.NotFound {
+			count, err := s.countTree(ref)
+			if err != nil {
+				return err
+			}
+
+			d := models.Diagnosis{
+				Kind:       models.OrphanDiagnosis,
+				Path:       location + "/",
+				Message:    fmt.Sprintf("%v documents are left without parent folder", count),
+				Suggestion: "Run doctor with --fix to remove them",
+			}
+			if fix {
+				d.Fixed = s.removeTree(ref) == nil
+			}
+
+			report.Add(d)
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		var node models.Node
+		if err := node.FromJson(snap.Data()); err != nil || len(node.Title) == 0 || (!node.IsFile() && !node.IsFolder()) {
+			report.Add(models.Diagnosis{
+				Kind:       models.UndecodableDiagnosis,
+				Path:       location,
+				Message:    "Document couldn't be decoded as a file or folder",
+				Suggestion: "Inspect the document via firebase console, and repair or remove it manually",
+			})
+			continue
+		}
+
+		title := strings.SplitN(location, "/", 2)[1]
+		s.diagnosePath(ref, node, title, report, fix)
+		s.diagnoseTimestamp(ref, snap.Data(), location, report, fix)
+
+		if node.IsFolder() {
+			if err := s.diagnoseNested(ref.Collection("sub"), report, fix); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// diagnoseFlat is the flat layout implementation of [Doctor].
+func (s *FirebaseService) diagnoseFlat(report *models.DoctorReport, fix bool) error {
+	collection := s.NotyaCollection()
+
+	docs, err := s.queryDocs(collection.Query)
+	if err != nil {
+		return err
+	}
+
+	folders := map[string]bool{}
+	nodes := []models.Node{}
+
+	for _, doc := range docs {
+		if pkg.IsIgnorable(doc.Ref.ID, models.NotyaIgnoreFiles) {
+			continue
+		}
+
+		var node models.Node
+		if err := node.FromJson(doc.Data()); err != nil || len(node.Title) == 0 || (!node.IsFile() && !node.IsFolder()) {
+			report.Add(models.Diagnosis{
+				Kind:       models.UndecodableDiagnosis,
+				Path:       collection.ID + "/" + doc.Ref.ID,
+				Message:    "Document couldn't be decoded as a file or folder",
+				Suggestion: "Inspect the document via firebase console, and repair or remove it manually",
+			})
+			continue
+		}
+
+		title, err := url.PathUnescape(doc.Ref.ID)
+		if err != nil {
+			title = doc.Ref.ID
+		}
+
+		s.diagnosePath(doc.Ref, node, title, report, fix)
+		s.diagnoseTimestamp(doc.Ref, doc.Data(), collection.ID+"/"+title, report, fix)
+
+		if node.IsFolder() {
+			folders[HashKey(title)] = true
+		}
+
+		node.Title = title
+		nodes = append(nodes, node)
+	}
+
+	// Find nodes, which's parent folder document doesn't exist.
+	for _, node := range nodes {
+		parent := FlatParent(node.Title)
+		if len(parent) == 0 || folders[HashKey(parent)] {
+			continue
+		}
+
+		d := models.Diagnosis{
+			Kind:       models.OrphanDiagnosis,
+			Path:       collection.ID + "/" + node.Title,
+			Message:    fmt.Sprintf("Parent folder %v doesn't exist", parent),
+			Suggestion: "Run doctor with --fix to re-create parent folder",
+		}
+
+		if fix {
+			d.Fixed = true
+			for p := parent; len(p) > 0 && !folders[HashKey(p)]; p = FlatParent(p) {
+				if _, err := s.Mkdir(models.Folder{Title: p}); err != nil {
+					d.Fixed = false
+					break
+				}
+
+				folders[HashKey(p)] = true
+			}
+		}
+
+		report.Add(d)
+	}
+
+	return nil
+}
+
+// diagnosePath checks whether the stored title and path of [node] match
+// the real location([title]) of its document, and updates them if [fix] is true.
+func (s *FirebaseService) diagnosePath(ref *firestore.DocumentRef, node models.Node, title string, report *models.DoctorReport, fix bool) {
+	if node.IsFolder() {
+		title = strings.Trim(title, "/") + "/"
+	}
+
+	collection := s.NotyaCollection()
+	expected := collection.ID + "/" + title
+	stored := node.GetPath(s.Type())
+
+	if strings.Trim(stored, "/") == strings.Trim(expected, "/") && HashKey(node.Title) == HashKey(title) {
+		return
+	}
+
+	d := models.Diagnosis{
+		Kind:       models.PathMismatchDiagnosis,
+		Path:       expected,
+		Message:    fmt.Sprintf("Stored title %q and path %q disagree with document location", node.Title, stored),
+		Suggestion: "Run doctor with --fix to update stored title and path",
+	}
+
+	if fix {
+		node.UpdatePath(s.Type(), expected)
+		err := s.updateDoc(ref, []firestore.Update{
+			{Path: "title", Value: title},
+			{Path: "path", Value: node.Path},
+		})
+
+		d.Fixed = err == nil
+	}
+
+	report.Add(d)
+}
+
+// diagnoseTimestamp checks whether the document [data] has modification time,
+// and backfills it by the server time if [fix] is true.
+func (s *FirebaseService) diagnoseTimestamp(ref *firestore.DocumentRef, data map[string]interface{}, location string, report *models.DoctorReport, fix bool) {
+	if _, ok := data["updated_at"]; ok {
+		return
+	}
+
+	d := models.Diagnosis{
+		Kind:       models.MissingTimestampDiagnosis,
+		Path:       location,
+		Message:    "Document has no modification time, so its changes aren't fetched incrementally",
+		Suggestion: "Run doctor with --fix to backfill modification time",
+	}
+
+	if fix {
+		err := s.updateDoc(ref, []firestore.Update{
+			{Path: "updated_at", Value: firestore.ServerTimestamp},
+		})
+
+		d.Fixed = err == nil
+	}
+
+	report.Add(d)
+}
+
+// docLocation converts the reference of nested layout document to nt path.
+// So, "<collection>/<folder>/sub/<note>" would be "<collection>/<folder>/<note>".
+func (s *FirebaseService) docLocation(ref *firestore.DocumentRef) string {
+	collection := s.NotyaCollection()
+
+	segments := strings.Split(strings.TrimPrefix(ref.Path, collection.Path+"/"), "/")
+	ids := []string{collection.ID}
+
+	// Even segments are document IDs, odd ones are "sub" collections.
+	for i := 0; i < len(segments); i += 2 {
+		ids = append(ids, segments[i])
+	}
+
+	return strings.Join(ids, "/")
+}
+
+// countTree counts all documents(including missing ones) under
+// the "sub" collection of given document, recursively.
+func (s *FirebaseService) countTree(doc *firestore.DocumentRef) (int, error) {
+	refs, err := s.listRefs(doc.Collection("sub"))
+	if err != nil {
+		return 0, err
+	}
+
+	count := len(refs)
+	for _, ref := range refs {
+		sub, err := s.countTree(ref)
+		if err != nil {
+			return 0, err
+		}
+
+		count += sub
+	}
+
+	return count, nil
+}
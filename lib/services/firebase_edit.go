@@ -0,0 +1,202 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services
+
+import (
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/insolite-dev/nt/assets"
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/pkg"
+)
+
+// EditID generates the journal key of a remote note edit.
+func EditID(service, collection, title string) string {
+	return pkg.Hash(service + "/" + collection + "/" + HashKey(title))[:16]
+}
+
+// EditsPath returns the cache directory, where remote notes are edited.
+func (s *FirebaseService) EditsPath() string {
+	base, _ := s.LS.Path()
+	return base + models.EditsName + "/"
+}
+
+// Journal reads the journal of in-progress edits.
+func (s *FirebaseService) Journal() models.Journal {
+	data, err := pkg.ReadBody(s.EditsPath() + models.JournalName)
+	if err != nil {
+		return models.Journal{}
+	}
+
+	return models.DecodeJournal(*data)
+}
+
+// writeJournal overwrites the journal of in-progress edits.
+func (s *FirebaseService) writeJournal(journal models.Journal) error {
+	if err := os.MkdirAll(s.EditsPath(), 0o750); err != nil {
+		return err
+	}
+
+	return pkg.WriteNote(s.EditsPath()+models.JournalName, journal.ToString())
+}
+
+// Unfinished returns in-progress edits of current collection,
+// that were left by crashed editors or failed write-backs.
+func (s *FirebaseService) Unfinished() []models.JournalEntry {
+	return s.Journal().Find(s.Type(), s.Config.FirePath())
+}
+
+// Open, opens a remote note in local machine.
+// Body of note is cached into [EditsPath] and journaled, before opening editor.
+// So, if the editor crashes or writing back fails, edits could be recovered.
+// Unchanged body isn't written back.
+func (s *FirebaseService) Open(node models.Node) error {
+	id := EditID(s.Type(), s.Config.FirePath(), node.Title)
+
+	journal := s.Journal()
+	if _, exists := journal[id]; exists {
+		return assets.UnfinishedEdit
+	}
+
+	data, err := s.View(node.ToNote())
+	if err != nil {
+		return err
+	}
+
+	entry := models.JournalEntry{
+		ID:         id,
+		Service:    s.Type(),
+		Collection: s.Config.FirePath(),
+		Title:      data.Title,
+		Path:       data.Path,
+		File:       s.EditsPath() + id + path.Ext(data.Title),
+		Hash:       pkg.Hash(data.Body),
+		UpdatedAt:  data.UpdatedAt,
+		StartedAt:  time.Now(),
+	}
+
+	if err := os.MkdirAll(s.EditsPath(), 0o750); err != nil {
+		return err
+	}
+
+	if err := pkg.WriteNote(entry.File, data.Body); err != nil {
+		return err
+	}
+
+	journal[id] = entry
+	if err := s.writeJournal(journal); err != nil {
+		_ = pkg.Delete(entry.File)
+		return err
+	}
+
+	return s.Resume(entry)
+}
+
+// Resume re-opens the cached body of [entry] via editor, and then recovers it.
+func (s *FirebaseService) Resume(entry models.JournalEntry) error {
+	if err := pkg.OpenViaEditor(entry.File, s.Stdargs, s.LS.StateConfig()); err != nil {
+		return err
+	}
+
+	return s.Recover(entry)
+}
+
+// Recover writes the cached body of [entry] back to remote, if it was changed.
+// Entry is removed from journal only after a successful write-back.
+//
+// If remote note was changed after it was read, [assets.EditConflict] is returned,
+// and entry is kept in journal to be resolved via [Resolve].
+func (s *FirebaseService) Recover(entry models.JournalEntry) error {
+	body, err := pkg.ReadBody(entry.File)
+	if err != nil {
+		return err
+	}
+
+	if pkg.Hash(*body) != entry.Hash {
+		note := models.Note{Title: entry.Title, Path: entry.Path, Body: *body, UpdatedAt: entry.UpdatedAt}
+		if _, err := s.Edit(note); err != nil {
+			return err
+		}
+	}
+
+	return s.Discard(entry)
+}
+
+// Discard removes the cached body of [entry], and drops it from journal.
+func (s *FirebaseService) Discard(entry models.JournalEntry) error {
+	if err := pkg.Delete(entry.File); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	journal := s.Journal()
+	delete(journal, entry.ID)
+
+	return s.writeJournal(journal)
+}
+
+// Available resolutions of edit conflicts.
+const (
+	// KeepMine overwrites remote note with the cached body.
+	KeepMine = "mine"
+
+	// KeepTheirs drops the cached body, and keeps remote note as is.
+	KeepTheirs = "theirs"
+
+	// SaveCopy saves the cached body as a new remote note, next to the original one.
+	SaveCopy = "copy"
+)
+
+// ConflictTitle generates the title of conflicted copy of note.
+// So, "todo/today.md" becomes "todo/today.conflict-20211208144312.md".
+func ConflictTitle(title string, t time.Time) string {
+	ext := path.Ext(title)
+	return strings.TrimSuffix(title, ext) + ".conflict-" + t.Format("20060102150405") + ext
+}
+
+// Theirs returns the current remote version of the note of [entry].
+func (s *FirebaseService) Theirs(entry models.JournalEntry) (*models.Note, error) {
+	return s.View(models.Note{Title: entry.Title, Path: entry.Path})
+}
+
+// Mine returns the cached body of [entry].
+func (s *FirebaseService) Mine(entry models.JournalEntry) (string, error) {
+	body, err := pkg.ReadBody(entry.File)
+	if err != nil {
+		return "", err
+	}
+
+	return *body, nil
+}
+
+// Resolve resolves the edit conflict of [entry] by given [resolution].
+// See: [KeepMine], [KeepTheirs], [SaveCopy].
+func (s *FirebaseService) Resolve(entry models.JournalEntry, resolution string) error {
+	switch resolution {
+	case KeepMine:
+		entry.UpdatedAt = time.Time{}
+		return s.Recover(entry)
+	case KeepTheirs:
+		return s.Discard(entry)
+	case SaveCopy:
+		body, err := s.Mine(entry)
+		if err != nil {
+			return err
+		}
+
+		note := models.Note{Title: ConflictTitle(entry.Title, time.Now()), Body: body}
+		if _, err := s.Create(note); err != nil {
+			return err
+		}
+
+		return s.Discard(entry)
+	}
+
+	return assets.InvalidResolution
+}
@@ -0,0 +1,46 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/insolite-dev/nt/lib/services"
+)
+
+func TestEditID(t *testing.T) {
+	got := services.EditID("FIREBASE", "nt", "todo/today.md")
+
+	if len(got) != 16 {
+		t.Errorf("EditID len is different, Want: %v | Got: %v", 16, len(got))
+	}
+
+	if other := services.EditID("FIREBASE", "nt-v2", "todo/today.md"); other == got {
+		t.Errorf("EditID of different collections must be different, Got: %v", other)
+	}
+}
+
+func TestConflictTitle(t *testing.T) {
+	mark := time.Date(2021, 12, 8, 14, 43, 12, 0, time.UTC)
+
+	tests := []struct {
+		title    string
+		expected string
+	}{
+		{title: "note.md", expected: "note.conflict-20211208144312.md"},
+		{title: "todo/today.md", expected: "todo/today.conflict-20211208144312.md"},
+		{title: "todo/today", expected: "todo/today.conflict-20211208144312"},
+	}
+
+	for _, td := range tests {
+		got := services.ConflictTitle(td.title, mark)
+		if got != td.expected {
+			t.Errorf("ConflictTitle sum is different, Want: %v | Got: %v", td.expected, got)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services
+
+import (
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/firestore"
+	"github.com/insolite-dev/nt/assets"
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/pkg"
+)
+
+// maxBatchWrites is the limit of writes that firestore allows in one batch.
+const maxBatchWrites = 500
+
+// FlatDocID generates the document ID of node, for the flat layout.
+// Slashes of title are escaped, so "todo/today.md" becomes "todo%2Ftoday.md".
+func FlatDocID(title string) string {
+	return url.PathEscape(strings.Trim(title, "/"))
+}
+
+// FlatParent generates the title of parent folder of node, for the flat layout.
+// Nodes at the root of collection have an empty parent.
+func FlatParent(title string) string {
+	dir := path.Dir(strings.Trim(title, "/"))
+	if dir == "." || dir == "/" {
+		return ""
+	}
+
+	return dir + "/"
+}
+
+// IsFlat checks if the collection is stored in flat layout.
+func (s *FirebaseService) IsFlat() bool {
+	return s.Config.FireLayout() == models.FlatLayout
+}
+
+// listFlat is the flat layout implementation of [ListDir].
+// Lists all nodes that are placed under [prefix] folder, by a single query.
+// Nil [rules] lists all nodes, without reading ignore files.
+func (s *FirebaseService) listFlat(prefix, typ string, rules pkg.IgnoreRules) ([]models.Node, []string, error) {
+	collection := s.NotyaCollection()
+	prefix = strings.Trim(prefix, "/")
+
+	query := collection.Query
+	if len(prefix) > 0 {
+		prefix += "/"
+		query = collection.Where("title", ">=", prefix).Where("title", "<", prefix+"\uf8ff")
+	}
+
+	docs, err := s.queryDocs(query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	all := []models.Node{}
+	for _, doc := range docs {
+		node, ok := DecodeDoc(doc.Data())
+		if !ok {
+			continue
+		}
+
+		if node.UpdatedAt.IsZero() {
+			node.UpdatedAt = doc.UpdateTime
+		}
+
+		if node.CreatedAt.IsZero() {
+			node.CreatedAt = doc.CreateTime
+		}
+
+		all = append(all, node)
+	}
+
+	if rules != nil {
+		rules = append(rules, nodeIgnoreRules(all)...)
+	}
+
+	nodes := []models.Node{}
+	for _, node := range all {
+		title := strings.Trim(node.Title, "/")
+		if len(title) == 0 || title+"/" == prefix || !pkg.IsType(typ, node.IsFolder()) {
+			continue
+		}
+
+		// Ignore the node, if it or one of its parents is ignorable.
+		if rules.Match(title, node.IsFolder()) {
+			continue
+		}
+
+		level := strings.Count(title, "/") - strings.Count(prefix, "/")
+		node.Pretty = []string{strings.Repeat("  ", level) + node.GenPretty(), path.Base(title)}
+
+		nodes = append(nodes, node)
+	}
+
+	titles := sortByPath(nodes)
+	return nodes, titles, nil
+}
+
+// nodeIgnoreRules collects the rules of ignore files(see [models.IgnoreFileName]),
+// that're among [nodes]. Rules of deeper folders are placed later, to take precedence.
+func nodeIgnoreRules(nodes []models.Node) pkg.IgnoreRules {
+	files := []models.Node{}
+	for _, n := range nodes {
+		if n.IsFile() && path.Base(HashKey(n.Title)) == models.IgnoreFileName {
+			files = append(files, n)
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return strings.Count(HashKey(files[i].Title), "/") < strings.Count(HashKey(files[j].Title), "/")
+	})
+
+	rules := pkg.IgnoreRules{}
+	for _, f := range files {
+		rules = append(rules, pkg.ParseIgnore(FlatParent(f.Title), strings.Split(f.Body, "\n"))...)
+	}
+
+	return rules
+}
+
+// sortByPath sorts nodes by their titles, so each folder is followed by its sub nodes.
+// Returns the titles of sorted nodes.
+func sortByPath(nodes []models.Node) []string {
+	key := func(n models.Node) string {
+		if n.IsFolder() {
+			return strings.Trim(n.Title, "/") + "/"
+		}
+
+		return strings.Trim(n.Title, "/")
+	}
+	sort.Slice(nodes, func(i, j int) bool { return key(nodes[i]) < key(nodes[j]) })
+
+	titles := []string{}
+	for _, node := range nodes {
+		titles = append(titles, node.Title)
+	}
+
+	return titles
+}
+
+// collectFlatNodes is the flat layout implementation of [collectNodes].
+func (s *FirebaseService) collectFlatNodes(rules pkg.IgnoreRules, nodes map[string]models.Node) error {
+	collection := s.NotyaCollection()
+
+	docs, err := s.queryDocs(collection.Select("typ", "title", "hash"))
+	if err != nil {
+		return err
+	}
+
+	for _, doc := range docs {
+		node, ok := DecodeDoc(doc.Data())
+		title := strings.Trim(node.Title, "/")
+		if !ok || rules.Match(title, node.IsFolder()) {
+			continue
+		}
+
+		node.Title = title
+		nodes[HashKey(title)] = node
+	}
+
+	return nil
+}
+
+// removeFlat is the flat layout implementation of [Remove].
+// Removes the document of node, and documents of its sub nodes, if node is a folder.
+func (s *FirebaseService) removeFlat(node models.Node) error {
+	doc, _ := s.GenerateDoc(nil, node)
+	refs := []*firestore.DocumentRef{doc}
+
+	if current, err := s.GetDoc(node); err == nil && current.IsFolder() {
+		sub, _, err := s.listFlat(current.Title, "", nil)
+		if err != nil {
+			return err
+		}
+
+		for _, n := range sub {
+			subDoc, _ := s.GenerateDoc(nil, n)
+			refs = append(refs, subDoc)
+		}
+	}
+
+	writes := []func(b *firestore.WriteBatch){}
+	for _, ref := range refs {
+		r := ref
+		writes = append(writes, func(b *firestore.WriteBatch) { b.Delete(r) })
+	}
+
+	return s.commitWrites(writes, true)
+}
+
+// renameFlat is the flat layout implementation of [Rename].
+// Moves the document of node, and documents of its sub nodes, in batches.
+func (s *FirebaseService) renameFlat(current, updated models.Node) error {
+	moves := []models.EditNode{{Current: current, New: updated}}
+
+	if current.IsFolder() {
+		sub, _, err := s.listFlat(current.Title, "", nil)
+		if err != nil {
+			return err
+		}
+
+		for _, n := range sub {
+			newN := n
+			newN = *newN.RebuildParent(current, updated, s.Type(), s.Config)
+
+			moves = append(moves, models.EditNode{Current: n, New: newN})
+		}
+	}
+
+	writes := []func(b *firestore.WriteBatch){}
+	for _, m := range moves {
+		currentDoc, _ := s.GenerateDoc(nil, m.Current)
+		newDoc, _ := s.GenerateDoc(nil, m.New)
+		data := s.GenerateData(m.New)
+
+		writes = append(writes,
+			func(b *firestore.WriteBatch) { b.Create(newDoc, data) },
+			func(b *firestore.WriteBatch) { b.Delete(currentDoc) },
+		)
+	}
+
+	return s.commitWrites(writes, false)
+}
+
+// commitWrites applies given writes via batches,
+// by splitting them appropriate to firestore's batch limit.
+// See [commitBatch] for [idempotent].
+func (s *FirebaseService) commitWrites(writes []func(b *firestore.WriteBatch), idempotent bool) error {
+	for start := 0; start < len(writes); start += maxBatchWrites {
+		end := start + maxBatchWrites
+		if end > len(writes) {
+			end = len(writes)
+		}
+
+		batch := s.FireStore.Batch()
+		for _, write := range writes[start:end] {
+			write(batch)
+		}
+
+		if err := s.commitBatch(batch, idempotent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MigrateLayout re-writes all documents of collection in the provided [layout],
+// and removes the documents of previous layout. The layout is saved to settings,
+// so every client of collection would use the new layout.
+//
+// Nodes at the root of collection have the same documents in both layouts,
+// so they're just overwritten.
+func (s *FirebaseService) MigrateLayout(layout string) ([]models.Node, []error) {
+	if layout != models.NestedLayout && layout != models.FlatLayout {
+		return nil, []error{assets.InvalidFirebaseLayout}
+	}
+
+	previous := s.Config
+	if previous.FireLayout() == layout {
+		return nil, nil
+	}
+
+	// All documents are migrated, including the ignored ones, since ignore
+	// rules are applied at listing. So, nodes are listed without rules.
+	var nodes []models.Node
+	var err error
+	if s.IsFlat() {
+		nodes, _, err = s.listFlat("", "", nil)
+	} else {
+		collection := s.NotyaCollection()
+		nodes, _, err = s.listDir(&collection, "", nil, 0)
+	}
+
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	// Sort nodes via title-len ascending order.
+	sort.Slice(
+		nodes,
+		func(i, j int) bool { return len(nodes[i].Title) < len(nodes[j].Title) },
+	)
+
+	// Generate document references of previous layout, before switching it.
+	oldDocs := map[int]*firestore.DocumentRef{}
+	for i, node := range nodes {
+		if strings.Contains(strings.Trim(node.Title, "/"), "/") {
+			oldDocs[i], _ = s.GenerateDoc(nil, node)
+		}
+	}
+
+	s.Config.FirebaseLayout = layout
+
+	writes := []func(b *firestore.WriteBatch){}
+	for _, node := range nodes {
+		doc, _ := s.GenerateDoc(nil, node)
+		data := s.GenerateData(node)
+
+		writes = append(writes, func(b *firestore.WriteBatch) { b.Set(doc, data) })
+	}
+
+	// Previous layout stays untouched, if writing of new layout fails.
+	if err := s.commitWrites(writes, true); err != nil {
+		s.Config = previous
+		return nil, []error{err}
+	}
+
+	if err := s.WriteSettings(s.Config); err != nil {
+		s.Config = previous
+		return nil, []error{err}
+	}
+
+	errs := []error{}
+	for i, doc := range oldDocs {
+		if err := s.deleteDoc(doc); err != nil {
+			errs = append(errs, assets.CannotDoSth("remove", nodes[i].Title, err))
+		}
+	}
+
+	return nodes, errs
+}
@@ -0,0 +1,51 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services_test
+
+import (
+	"testing"
+
+	"github.com/insolite-dev/nt/lib/services"
+)
+
+func TestFlatDocID(t *testing.T) {
+	tests := []struct {
+		title    string
+		expected string
+	}{
+		{title: "note.md", expected: "note.md"},
+		{title: "todo/", expected: "todo"},
+		{title: "todo/today.md", expected: "todo%2Ftoday.md"},
+		{title: "todo/my note.md", expected: "todo%2Fmy%20note.md"},
+	}
+
+	for _, td := range tests {
+		got := services.FlatDocID(td.title)
+		if got != td.expected {
+			t.Errorf("FlatDocID sum is different, Want: %v | Got: %v", td.expected, got)
+		}
+	}
+}
+
+func TestFlatParent(t *testing.T) {
+	tests := []struct {
+		title    string
+		expected string
+	}{
+		{title: "note.md", expected: ""},
+		{title: "todo/", expected: ""},
+		{title: "todo/today.md", expected: "todo/"},
+		{title: "todo/insolite/issues.txt", expected: "todo/insolite/"},
+	}
+
+	for _, td := range tests {
+		got := services.FlatParent(td.title)
+		if got != td.expected {
+			t.Errorf("FlatParent sum is different, Want: %v | Got: %v", td.expected, got)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/insolite-dev/nt/assets"
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/pkg"
+)
+
+// mirrorPath returns the path of local mirror file.
+func (s *FirebaseService) mirrorPath() string {
+	base, _ := s.LS.Path()
+	return base + models.MirrorName
+}
+
+// Mirror reads the local mirror of current collection.
+// Result is nil, if collection wasn't mirrored yet.
+func (s *FirebaseService) Mirror() *models.Mirror {
+	data, err := pkg.ReadBody(s.mirrorPath())
+	if err != nil {
+		return nil
+	}
+
+	mirror := models.DecodeMirror(*data)
+	if mirror.Service != s.Type() || mirror.Collection != s.Config.FirePath() {
+		return nil
+	}
+
+	return &mirror
+}
+
+// mirrorRescanPeriod is the period of full scans of mirrored collection.
+const mirrorRescanPeriod = 24 * time.Hour
+
+// RefreshMirror updates the local mirror of current collection.
+//
+// Only nodes that were modified after the last refresh are read, so refreshing
+// costs as many reads as the changed nodes. Removed nodes can't be detected that
+// way, so the whole collection is scanned once per [mirrorRescanPeriod].
+func (s *FirebaseService) RefreshMirror() error {
+	if s.Offline {
+		return nil
+	}
+
+	mirror := s.Mirror()
+	if mirror == nil {
+		mirror = &models.Mirror{Service: s.Type(), Collection: s.Config.FirePath()}
+	}
+
+	since := mirror.Mark
+	if time.Since(mirror.ScannedAt) > mirrorRescanPeriod {
+		since = time.Time{}
+	}
+
+	changed, mark, err := s.GetChanged(since, models.NotyaIgnoreFiles)
+	if err != nil {
+		return err
+	}
+
+	if since.IsZero() {
+		mirror.Nodes = map[string]models.Node{}
+		mirror.ScannedAt = time.Now()
+	}
+
+	for _, node := range changed {
+		node.Pretty = nil
+		mirror.Nodes[HashKey(node.Title)] = node
+	}
+
+	mirror.Mark = mark
+	mirror.RefreshedAt = time.Now()
+
+	return pkg.WriteNote(s.mirrorPath(), mirror.ToString())
+}
+
+// forgetMirrored drops the mirrored node of [title] with its sub nodes,
+// after it's removed(or renamed) from remote.
+func (s *FirebaseService) forgetMirrored(title string) {
+	mirror := s.Mirror()
+	if mirror == nil {
+		return
+	}
+
+	key := HashKey(title)
+	for k := range mirror.Nodes {
+		if k == key || strings.HasPrefix(k, key+"/") {
+			delete(mirror.Nodes, k)
+		}
+	}
+
+	_ = pkg.WriteNote(s.mirrorPath(), mirror.ToString())
+}
+
+// listMirror is the offline implementation of [GetAll].
+// Lists mirrored nodes that are placed under [additional] folder.
+func (s *FirebaseService) listMirror(additional, typ string, ignore []string) ([]models.Node, []string, error) {
+	mirror := s.Mirror()
+	if mirror == nil {
+		return nil, nil, assets.NoMirror
+	}
+
+	prefix := strings.Trim(additional, "/")
+	if len(prefix) > 0 {
+		prefix += "/"
+	}
+
+	all := []models.Node{}
+	for _, node := range mirror.Nodes {
+		all = append(all, node)
+	}
+
+	rules := append(pkg.ParseIgnore("", ignore), nodeIgnoreRules(all)...)
+
+	nodes := []models.Node{}
+	for key, node := range mirror.Nodes {
+		if !strings.HasPrefix(key, prefix) || key+"/" == prefix || !pkg.IsType(typ, node.IsFolder()) {
+			continue
+		}
+
+		// Ignore the node, if it or one of its parents is ignorable.
+		if rules.Match(key, node.IsFolder()) {
+			continue
+		}
+
+		level := strings.Count(key, "/") - strings.Count(prefix, "/")
+		node.Pretty = []string{strings.Repeat("  ", level) + node.GenPretty(), path.Base(key)}
+
+		nodes = append(nodes, node)
+	}
+
+	titles := sortByPath(nodes)
+	return nodes, titles, nil
+}
+
+// viewMirror is the offline implementation of [View].
+func (s *FirebaseService) viewMirror(note models.Note) (*models.Note, error) {
+	mirror := s.Mirror()
+	if mirror == nil {
+		return nil, assets.NoMirror
+	}
+
+	node, exists := mirror.Nodes[HashKey(note.Title)]
+	if !exists || !node.IsFile() {
+		return nil, assets.NotExists(note.Title, "File")
+	}
+
+	model := node.ToNote()
+	model.Meta, _ = models.ParseFrontMatter(model.Body)
+
+	return &model, nil
+}
@@ -0,0 +1,300 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services
+
+import (
+	"strings"
+	"time"
+
+	"github.com/insolite-dev/nt/assets"
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/pkg"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// IsUnreachable checks if given error is caused by an unreachable remote.
+func IsUnreachable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+
+	return false
+}
+
+// queuePath returns the path of local operation queue file.
+func (s *FirebaseService) queuePath() string {
+	base, _ := s.LS.Path()
+	return base + models.QueueName
+}
+
+// readQueue reads all operations of local operation queue.
+func (s *FirebaseService) readQueue() models.Queue {
+	data, err := pkg.ReadBody(s.queuePath())
+	if err != nil {
+		return models.Queue{}
+	}
+
+	return models.DecodeQueue(*data)
+}
+
+// writeQueue overwrites local operation queue.
+func (s *FirebaseService) writeQueue(queue models.Queue) error {
+	return pkg.WriteNote(s.queuePath(), queue.ToString())
+}
+
+// Queue returns the queued operations of current collection.
+func (s *FirebaseService) Queue() models.Queue {
+	return s.readQueue().Find(s.Type(), s.Config.FirePath())
+}
+
+// enqueue records given operation to local operation queue.
+func (s *FirebaseService) enqueue(op models.Operation) error {
+	op.Service = s.Type()
+	op.Collection = s.Config.FirePath()
+	op.QueuedAt = time.Now()
+	op.ID = pkg.Hash(op.Kind + op.Title + op.QueuedAt.String())[:8]
+
+	return s.writeQueue(append(s.readQueue(), op))
+}
+
+// expectedNodes returns the content hashes of remote nodes, as they'd be after
+// replaying the queued operations of current collection. See [ExpectedHashes].
+func (s *FirebaseService) expectedNodes() map[string]string {
+	var mirrored map[string]models.Node
+	if mirror := s.Mirror(); mirror != nil {
+		mirrored = mirror.Nodes
+	}
+
+	return ExpectedHashes(mirrored, s.Queue())
+}
+
+// ExpectedHashes applies the operations of [queue] to the content hashes of
+// [mirrored] nodes, and returns the hashes that remote would have after replay.
+// Folders are included with empty hashes.
+func ExpectedHashes(mirrored map[string]models.Node, queue models.Queue) map[string]string {
+	nodes := map[string]string{}
+	for key, node := range mirrored {
+		if node.IsFile() && len(node.Hash) == 0 {
+			node.Hash = pkg.Hash(node.Body)
+		}
+
+		nodes[key] = node.Hash
+	}
+
+	for _, op := range queue {
+		key := HashKey(op.Title)
+
+		switch op.Kind {
+		case models.CreateOperation, models.EditOperation:
+			nodes[key] = pkg.Hash(op.Body)
+		case models.MkdirOperation:
+			nodes[key] = ""
+		case models.RemoveOperation, models.RenameOperation:
+			newKey := HashKey(op.NewTitle)
+			for k, hash := range nodes {
+				if k != key && !strings.HasPrefix(k, key+"/") {
+					continue
+				}
+
+				delete(nodes, k)
+				if op.Kind == models.RenameOperation {
+					nodes[newKey+strings.TrimPrefix(k, key)] = hash
+				}
+			}
+		}
+	}
+
+	return nodes
+}
+
+// baseHash returns the expected content hash of remote node at [title].
+// See [expectedNodes].
+func (s *FirebaseService) baseHash(title string) string {
+	return s.expectedNodes()[HashKey(title)]
+}
+
+// Drop removes the operation of given [id] from local operation queue.
+func (s *FirebaseService) Drop(id string) error {
+	queue := s.readQueue()
+	for _, op := range queue {
+		if op.ID == id {
+			return s.writeQueue(queue.Drop(id))
+		}
+	}
+
+	return assets.NotExists("", "Operation "+id)
+}
+
+// Replay applies queued operations of current collection in order of recording.
+// Applied operations are removed from queue, failed ones are kept with their errors.
+//
+// Conflicted operations are skipped, unless [force] is true. Forced replay
+// applies operations without checking remote changes.
+// If remote gets unreachable, replaying stops and remaining operations are kept.
+func (s *FirebaseService) Replay(force bool) ([]models.Operation, []error) {
+	if s.Offline {
+		return nil, nil
+	}
+
+	queue := s.readQueue()
+
+	replayed := []models.Operation{}
+	errs := []error{}
+	remaining := models.Queue{}
+
+	unreachable := false
+	for _, op := range queue {
+		if unreachable || op.Service != s.Type() || op.Collection != s.Config.FirePath() || (op.Conflict && !force) {
+			remaining = append(remaining, op)
+			continue
+		}
+
+		err := s.apply(op, force)
+		if err == nil {
+			replayed = append(replayed, op)
+			continue
+		}
+
+		unreachable = IsUnreachable(err)
+
+		op.Attempts++
+		op.LastError = err.Error()
+		op.Conflict = err == assets.OperationConflict || err == assets.EditConflict
+
+		remaining = append(remaining, op)
+		errs = append(errs, assets.CannotDoSth(op.Kind, op.Title, err))
+	}
+
+	if err := s.writeQueue(remaining); err != nil {
+		errs = append(errs, err)
+	}
+
+	return replayed, errs
+}
+
+// apply applies given operation to remote.
+// Unless [force] is true, remote is checked for changes made after
+// the operation was queued(by comparing its hash with [op.BaseHash]),
+// and [assets.OperationConflict] is returned if the operation can't be applied safely.
+func (s *FirebaseService) apply(op models.Operation, force bool) error {
+	node := models.Node{Type: op.Type, Title: op.Title}
+
+	// changed checks whether the remote note of operation differs from its base.
+	changed := func() (bool, error) {
+		remote, err := s.View(models.Note{Title: op.Title})
+		if err != nil {
+			return false, err
+		}
+
+		return remote.Hash != op.BaseHash, nil
+	}
+
+	switch op.Kind {
+	case models.CreateOperation:
+		if !force {
+			if remote, err := s.View(models.Note{Title: op.Title}); err == nil {
+				// Note was already created with same body.
+				if remote.Hash == pkg.Hash(op.Body) {
+					return nil
+				}
+
+				return assets.OperationConflict
+			}
+		}
+
+		_, err := s.Create(models.Note{Title: op.Title, Body: op.Body})
+		return err
+
+	case models.EditOperation:
+		note := models.Note{Title: op.Title, Body: op.Body}
+		if !force {
+			note.UpdatedAt = op.BaseTime
+
+			remote, err := s.View(models.Note{Title: op.Title})
+			if err != nil {
+				return err
+			}
+
+			if remote.Hash == pkg.Hash(op.Body) {
+				return nil
+			}
+
+			// Operations queued by older versions have no base hash,
+			// so they're checked only by their base time.
+			if len(op.BaseHash) > 0 && remote.Hash != op.BaseHash {
+				return assets.OperationConflict
+			}
+		}
+
+		_, err := s.Edit(note)
+		return err
+
+	case models.RemoveOperation:
+		if exists, err := s.IsNodeExists(node); err != nil {
+			return err
+		} else if !exists {
+			return nil
+		}
+
+		if !force && len(op.BaseHash) > 0 {
+			if differs, err := changed(); err != nil {
+				return err
+			} else if differs {
+				return assets.OperationConflict
+			}
+		}
+
+		return s.Remove(node)
+
+	case models.RenameOperation:
+		updated := models.Node{Type: op.Type, Title: op.NewTitle}
+
+		if !force {
+			currentExists, err := s.IsNodeExists(node)
+			if err != nil {
+				return err
+			}
+
+			updatedExists, err := s.IsNodeExists(updated)
+			if err != nil {
+				return err
+			}
+
+			// Node was already renamed.
+			if !currentExists && updatedExists {
+				return nil
+			}
+
+			if !currentExists || updatedExists {
+				return assets.OperationConflict
+			}
+
+			if len(op.BaseHash) > 0 {
+				if differs, err := changed(); err != nil {
+					return err
+				} else if differs {
+					return assets.OperationConflict
+				}
+			}
+		}
+
+		return s.Rename(models.EditNode{Current: node, New: updated})
+
+	case models.MkdirOperation:
+		// Folders have no content, so existing folder is the expected result.
+		if exists, err := s.IsNodeExists(node); err != nil || exists {
+			return err
+		}
+
+		_, err := s.Mkdir(models.Folder{Title: op.Title})
+		return err
+	}
+
+	return assets.InvalidOperation
+}
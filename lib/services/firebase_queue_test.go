@@ -0,0 +1,56 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/lib/services"
+	"github.com/insolite-dev/nt/pkg"
+)
+
+func TestExpectedHashes(t *testing.T) {
+	mirrored := map[string]models.Node{
+		"todo":          {Title: "todo/", Type: models.FOLDER},
+		"todo/today.md": {Title: "todo/today.md", Type: models.FILE, Hash: "a"},
+		"ideas.md":      {Title: "ideas.md", Type: models.FILE, Body: "old"},
+	}
+
+	tests := []struct {
+		testName string
+		queue    models.Queue
+		expected map[string]string
+	}{
+		{
+			testName: "should use mirrored hashes without operations",
+			queue:    models.Queue{},
+			expected: map[string]string{"todo": "", "todo/today.md": "a", "ideas.md": pkg.Hash("old")},
+		},
+		{
+			testName: "should apply queued operations in order",
+			queue: models.Queue{
+				{Kind: models.EditOperation, Title: "ideas.md", Body: "new"},
+				{Kind: models.MkdirOperation, Title: "done/"},
+				{Kind: models.CreateOperation, Title: "done/today.md", Body: "b"},
+				{Kind: models.RenameOperation, Title: "todo/", NewTitle: "later/"},
+				{Kind: models.RemoveOperation, Title: "done/"},
+			},
+			expected: map[string]string{"later": "", "later/today.md": "a", "ideas.md": pkg.Hash("new")},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			got := services.ExpectedHashes(mirrored, td.queue)
+			if !reflect.DeepEqual(got, td.expected) {
+				t.Errorf("ExpectedHashes sum was different: Want: %v | Got: %v", td.expected, got)
+			}
+		})
+	}
+}
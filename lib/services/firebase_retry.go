@@ -0,0 +1,183 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/insolite-dev/nt/lib/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// IsTransient checks if given error is a temporary failure of firestore,
+// so the failed request is worth retrying.
+func IsTransient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+
+	return false
+}
+
+// IsRejected checks if given error is a temporary failure of firestore, that
+// means the request was rejected without being applied. Unlike other transient
+// failures, outcome of the request isn't ambiguous, so even non-idempotent
+// writes(create, update) are safe to retry.
+func IsRejected(err error) bool {
+	return status.Code(err) == codes.ResourceExhausted
+}
+
+// Backoff generates the delay before the [attempt]th retry(starts from zero).
+// Delay doubles after each retry, up to [policy.MaxBackoff], and a random
+// jitter of up to a half of it is subtracted, to not retry all requests at once.
+func Backoff(policy models.RetryPolicy, attempt int) time.Duration {
+	delay := policy.Backoff
+	for i := 0; i < attempt && delay < policy.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > policy.MaxBackoff {
+		delay = policy.MaxBackoff
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// retry runs [fn], and re-runs it while it fails by a transient error,
+// appropriate to the retry policy of settings.
+// Must be used only for reads and idempotent writes, see [retryRejected].
+//
+// If [fn] succeeds after retrying, [label] is appended to [s.Retried].
+func (s *FirebaseService) retry(label string, fn func() error) error {
+	return s.retryOn(IsTransient, label, fn)
+}
+
+// retryRejected is the [retry] of non-idempotent writes.
+// Which re-runs [fn] only if it was rejected without being applied, see [IsRejected].
+// Otherwise a re-run could fail because of the first attempt's own result.
+func (s *FirebaseService) retryRejected(label string, fn func() error) error {
+	return s.retryOn(IsRejected, label, fn)
+}
+
+// retryOn is the base implementation of [retry] and [retryRejected].
+// Which re-runs [fn] while it fails by an error, that's [retriable].
+func (s *FirebaseService) retryOn(retriable func(err error) bool, label string, fn func() error) error {
+	policy := s.Config.FireRetryPolicy()
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		if err = fn(); err == nil {
+			if attempt > 0 {
+				s.Retried = append(s.Retried, label)
+			}
+
+			return nil
+		}
+
+		if !retriable(err) || attempt >= policy.Retries {
+			return err
+		}
+
+		time.Sleep(Backoff(policy, attempt))
+	}
+}
+
+// docLabel generates the label of document reference, relative to the database.
+// So, "projects/<id>/databases/(default)/documents/nt/todo" becomes "nt/todo".
+func docLabel(ref *firestore.DocumentRef) string {
+	segments := strings.SplitN(ref.Path, "/documents/", 2)
+	return segments[len(segments)-1]
+}
+
+// fetchDoc gets the snapshot of given document, with retrying.
+func (s *FirebaseService) fetchDoc(ref *firestore.DocumentRef) (*firestore.DocumentSnapshot, error) {
+	var snap *firestore.DocumentSnapshot
+	err := s.retry(docLabel(ref), func() error {
+		var err error
+		snap, err = ref.Get(s.Ctx)
+		return err
+	})
+
+	return snap, err
+}
+
+// createDoc creates given document, with retrying of rejected requests.
+func (s *FirebaseService) createDoc(ref *firestore.DocumentRef, data interface{}) error {
+	return s.retryRejected(docLabel(ref), func() error {
+		_, err := ref.Create(s.Ctx, data)
+		return err
+	})
+}
+
+// setDoc overwrites(or merges, by [opts]) given document, with retrying.
+func (s *FirebaseService) setDoc(ref *firestore.DocumentRef, data interface{}, opts ...firestore.SetOption) error {
+	return s.retry(docLabel(ref), func() error {
+		_, err := ref.Set(s.Ctx, data, opts...)
+		return err
+	})
+}
+
+// updateDoc updates fields of given document, with retrying of rejected requests.
+// Updates could have preconditions or server generated values, so they aren't idempotent.
+func (s *FirebaseService) updateDoc(ref *firestore.DocumentRef, updates []firestore.Update, preconds ...firestore.Precondition) error {
+	return s.retryRejected(docLabel(ref), func() error {
+		_, err := ref.Update(s.Ctx, updates, preconds...)
+		return err
+	})
+}
+
+// deleteDoc deletes given document, with retrying.
+func (s *FirebaseService) deleteDoc(ref *firestore.DocumentRef) error {
+	return s.retry(docLabel(ref), func() error {
+		_, err := ref.Delete(s.Ctx)
+		return err
+	})
+}
+
+// queryDocs gets all documents of given query, with retrying.
+// Whole query is re-run on failure, so no document is dropped silently.
+func (s *FirebaseService) queryDocs(query firestore.Query) ([]*firestore.DocumentSnapshot, error) {
+	var docs []*firestore.DocumentSnapshot
+	err := s.retry("query", func() error {
+		var err error
+		docs, err = query.Documents(s.Ctx).GetAll()
+		return err
+	})
+
+	return docs, err
+}
+
+// listRefs gets all document references(including missing ones) of given collection, with retrying.
+func (s *FirebaseService) listRefs(collection *firestore.CollectionRef) ([]*firestore.DocumentRef, error) {
+	var refs []*firestore.DocumentRef
+	err := s.retry(strings.SplitN(collection.Path, "/documents/", 2)[1], func() error {
+		var err error
+		refs, err = collection.DocumentRefs(s.Ctx).GetAll()
+		return err
+	})
+
+	return refs, err
+}
+
+// commitBatch commits given batch, with retrying.
+// Batches that aren't [idempotent](e.g. include creates) are retried only if they were rejected.
+func (s *FirebaseService) commitBatch(batch *firestore.WriteBatch, idempotent bool) error {
+	retry := s.retryRejected
+	if idempotent {
+		retry = s.retry
+	}
+
+	return retry("batch", func() error {
+		_, err := batch.Commit(s.Ctx)
+		return err
+	})
+}
@@ -0,0 +1,81 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/lib/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{err: nil, expected: false},
+		{err: errors.New("mock"), expected: false},
+		{err: status.Error(codes.NotFound, "mock"), expected: false},
+		{err: status.Error(codes.Unavailable, "mock"), expected: true},
+		{err: status.Error(codes.DeadlineExceeded, "mock"), expected: true},
+		{err: status.Error(codes.ResourceExhausted, "mock"), expected: true},
+		{err: status.Error(codes.Aborted, "mock"), expected: true},
+	}
+
+	for _, td := range tests {
+		got := services.IsTransient(td.err)
+		if got != td.expected {
+			t.Errorf("IsTransient(%v) sum is different, Want: %v | Got: %v", td.err, td.expected, got)
+		}
+	}
+}
+
+func TestIsRejected(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{err: nil, expected: false},
+		{err: errors.New("mock"), expected: false},
+		{err: status.Error(codes.Unavailable, "mock"), expected: false},
+		{err: status.Error(codes.DeadlineExceeded, "mock"), expected: false},
+		{err: status.Error(codes.Aborted, "mock"), expected: false},
+		{err: status.Error(codes.ResourceExhausted, "mock"), expected: true},
+	}
+
+	for _, td := range tests {
+		got := services.IsRejected(td.err)
+		if got != td.expected {
+			t.Errorf("IsRejected(%v) sum is different, Want: %v | Got: %v", td.err, td.expected, got)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := models.RetryPolicy{Retries: 4, Backoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{attempt: 0, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{attempt: 2, min: 200 * time.Millisecond, max: 400 * time.Millisecond},
+		{attempt: 10, min: 500 * time.Millisecond, max: time.Second},
+	}
+
+	for _, td := range tests {
+		got := services.Backoff(policy, td.attempt)
+		if got < td.min || got > td.max {
+			t.Errorf("Backoff(%v) sum is out of range, Want: [%v, %v] | Got: %v", td.attempt, td.min, td.max, got)
+		}
+	}
+}
@@ -8,6 +8,7 @@ package services
 
 import (
 	"context"
+	"path"
 	"sort"
 	"strings"
 	"time"
@@ -20,7 +21,6 @@ import (
 	"github.com/insolite-dev/nt/lib/models"
 	"github.com/insolite-dev/nt/pkg"
 	"github.com/mitchellh/mapstructure"
-	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -39,6 +39,18 @@ type FirebaseService struct {
 	FireApp   *firebase.App
 	FireAuth  *auth.Client
 	FireStore *firestore.Client
+
+	// Offline represents whether the firebase was unreachable at initialization.
+	// Mutating operations of offline service are queued, instead of being applied.
+	Offline bool
+
+	// Retried is the list of documents(or queries), which's requests
+	// were failed by a transient error, but succeeded after retrying.
+	Retried []string
+
+	// Warnings is the list of degraded behaviours of service, that the user
+	// should be informed about. e.g. falling back to a full scan of collection.
+	Warnings []string
 }
 
 // Mark [FirebaseService] as [ServiceRepo].
@@ -94,6 +106,9 @@ func (s *FirebaseService) GeneratePath(base *firestore.CollectionRef, n models.N
 //
 // In case of being node [Folder] type. The collection reference return will be the "sub" collection
 // of generated document. But for the [File] type, it'd return nil.
+//
+// In flat layout, the document is always a top-level document of collection, keyed by [FlatDocID].
+// And there is no "sub" collection, so the returned collection reference would be nil.
 func (s *FirebaseService) GenerateDoc(base *firestore.CollectionRef, n models.Node) (*firestore.DocumentRef, *firestore.CollectionRef) {
 	path, collection := s.GeneratePath(base, n)
 
@@ -102,6 +117,10 @@ func (s *FirebaseService) GenerateDoc(base *firestore.CollectionRef, n models.No
 		segments = segments[1:]
 	}
 
+	if s.IsFlat() {
+		return collection.Doc(FlatDocID(strings.Join(segments, "/"))), nil
+	}
+
 	doc := *collection.Doc(segments[0])
 	for i := 1; i < len(segments); i++ {
 		if len(segments[i]) != 0 {
@@ -116,13 +135,58 @@ func (s *FirebaseService) GenerateDoc(base *firestore.CollectionRef, n models.No
 	return &doc, doc.Collection("sub")
 }
 
+// GenerateData converts provided node to firestore document data.
+// The [updated_at] field of document is always set by server, to make
+// document be queryable by its last modification time. And the [hash]
+// field is re-generated from body, to make it comparable without the body.
+//
+// Metadata fields are re-generated too: [size] from body, [updated_by] from
+// current user, and [created_at] is set by server, if node hasn't got one.
+// Since [created_at] is a part of data, it should be used only to create
+// documents. See [FirebaseService.Edit].
+func (s *FirebaseService) GenerateData(n models.Node) map[string]interface{} {
+	if n.IsFile() {
+		n.Hash = pkg.Hash(n.Body)
+		n.Size = int64(len(n.Body))
+	}
+
+	n.UpdatedBy = pkg.Author()
+
+	data := n.ToJSON()
+	data["updated_at"] = firestore.ServerTimestamp
+
+	if n.CreatedAt.IsZero() {
+		data["created_at"] = firestore.ServerTimestamp
+	} else {
+		data["created_at"] = n.CreatedAt
+	}
+
+	if s.IsFlat() {
+		data["parent"] = FlatParent(n.Title)
+	}
+
+	return data
+}
+
+// DecodeDoc decodes the [data] of a firestore document to node. Result is false for
+// documents that aren't nodes, like the settings document(see [models.SettingsName]),
+// which is kept at the collection of nodes by default, or undecodable documents.
+func DecodeDoc(data map[string]interface{}) (models.Node, bool) {
+	var node models.Node
+	if err := node.FromJson(data); err != nil || len(strings.Trim(node.Title, "/")) == 0 {
+		return node, false
+	}
+
+	return node, true
+}
+
 // GetDoc is a function that used to get document reference as [models.Node].
 func (s *FirebaseService) GetDoc(n models.Node) (*models.Node, error) {
 	path, _ := s.GeneratePath(nil, n)
 	n.UpdatePath(s.Type(), path)
 
 	nDoc, _ := s.GenerateDoc(nil, n)
-	docSnapshot, err := nDoc.Get(s.Ctx)
+	docSnapshot, err := s.fetchDoc(nDoc)
 
 	if err != nil {
 		if status, ok := status.FromError(err); ok && status.Code() == codes.NotFound {
@@ -179,7 +243,10 @@ func (s *FirebaseService) Init(settings *models.Settings) error {
 	}
 
 	config, err := s.Settings(nil)
-	if status.Code(err) == codes.NotFound {
+	if IsUnreachable(err) {
+		s.Offline = true
+		return nil
+	} else if status.Code(err) == codes.NotFound {
 		if err := s.WriteSettings(s.Config); err != nil {
 			return err
 		}
@@ -226,7 +293,7 @@ func (s *FirebaseService) Settings(p *string) (*models.Settings, error) {
 	}
 
 	collection := s.FireStore.Collection(s.Config.Name)
-	docSnap, err := collection.Doc(sp).Get(s.Ctx)
+	docSnap, err := s.fetchDoc(collection.Doc(sp))
 	if err != nil {
 		return nil, err
 	}
@@ -244,7 +311,7 @@ func (s *FirebaseService) WriteSettings(settings models.Settings) error {
 	}
 
 	collection := s.FireStore.Collection(s.Config.Name)
-	if _, err := collection.Doc(models.SettingsName).Set(s.Ctx, settings.ToJSON()); err != nil {
+	if err := s.setDoc(collection.Doc(models.SettingsName), settings.ToJSON()); err != nil {
 		return err
 	}
 
@@ -252,9 +319,15 @@ func (s *FirebaseService) WriteSettings(settings models.Settings) error {
 }
 
 // IsNodeExists checks if an element(given node) exists at nt collection or not.
+// Offline service checks the local mirror, with queued operations applied to it.
 func (s *FirebaseService) IsNodeExists(node models.Node) (bool, error) {
+	if s.Offline {
+		_, exists := s.expectedNodes()[HashKey(node.Title)]
+		return exists, nil
+	}
+
 	doc, _ := s.GenerateDoc(nil, node)
-	if _, err := doc.Get(s.Ctx); err != nil {
+	if _, err := s.fetchDoc(doc); err != nil {
 		if status, ok := status.FromError(err); ok && status.Code() == codes.NotFound {
 			return false, nil
 		}
@@ -304,47 +377,19 @@ func (s *FirebaseService) OpenSettings(settings models.Settings) error {
 	return nil
 }
 
-// Open, opens a remote note in local machine.
-// clones it on local, makes able to modify, after modifying, overwrites on it db.
-func (s *FirebaseService) Open(node models.Node) error {
-	data, err := s.View(node.ToNote())
-	if err != nil {
-		return err
-	}
-
-	splitted := strings.Split(data.Title, "/")
-	note := models.Note{Title: splitted[len(splitted)-1] + time.Now().String(), Body: data.Body}
-	if _, err := s.LS.Create(note); err != nil {
-		return err
-	}
-
-	// Open via editor to edit.
-	openErr := s.LS.Open(note.ToNode())
-	if openErr != nil {
-		return openErr
-	}
-
-	// Get updated note.
-	updatedNote, err := s.LS.View(note)
-	if err != nil {
-		return err
-	}
-
-	// Clear cache, and skip error.
-	_ = s.LS.Remove(updatedNote.ToNode())
-
-	note = models.Note{Title: data.Title, Path: data.Path, Body: updatedNote.Body}
-	if _, err := s.Edit(note); err != nil {
-		return err
-	}
-
-	return nil
-}
-
 // Remove deletes given node from [node.Path].
 // If a node doesn't exists at provided note's path,
 // it will return a already formatted error message.
 func (s *FirebaseService) Remove(node models.Node) error {
+	if s.Offline {
+		base := node.Hash
+		if len(base) == 0 {
+			base = s.baseHash(node.Title)
+		}
+
+		return s.enqueue(models.Operation{Kind: models.RemoveOperation, Type: node.Type, Title: node.Title, BaseHash: base})
+	}
+
 	n := node
 
 	path, _ := s.GeneratePath(nil, n)
@@ -356,16 +401,53 @@ func (s *FirebaseService) Remove(node models.Node) error {
 		return assets.NotExists(n.Title, "File or Directory")
 	}
 
-	noteDoc, _ := s.GenerateDoc(nil, n)
-	if _, err := noteDoc.Delete(s.Ctx); err != nil {
+	var err error
+	if s.IsFlat() {
+		err = s.removeFlat(n)
+	} else {
+		// Sub documents of folder must be removed too, otherwise
+		// they'd stay in database without any visible parent.
+		noteDoc, _ := s.GenerateDoc(nil, n)
+		err = s.removeTree(noteDoc)
+	}
+
+	if err == nil {
+		s.forgetMirrored(n.Title)
+	}
+
+	return err
+}
+
+// removeTree deletes the given document with all documents of its "sub" collection, recursively.
+// Documents that exist only as a parent of sub collection are included too.
+func (s *FirebaseService) removeTree(doc *firestore.DocumentRef) error {
+	refs, err := s.listRefs(doc.Collection("sub"))
+	if err != nil {
 		return err
 	}
 
-	return nil
+	for _, ref := range refs {
+		if err := s.removeTree(ref); err != nil {
+			return err
+		}
+	}
+
+	err = s.deleteDoc(doc)
+	return err
 }
 
 // Rename changes reference ID of document.
 func (s *FirebaseService) Rename(editNode models.EditNode) error {
+	if s.Offline {
+		return s.enqueue(models.Operation{
+			Kind:     models.RenameOperation,
+			Type:     editNode.Current.Type,
+			Title:    editNode.Current.Title,
+			NewTitle: editNode.New.Title,
+			BaseHash: s.baseHash(editNode.Current.Title),
+		})
+	}
+
 	current, err := s.GetDoc(editNode.Current)
 	if err != nil {
 		return err
@@ -389,6 +471,15 @@ func (s *FirebaseService) Rename(editNode models.EditNode) error {
 		return assets.AlreadyExists(updated.Title, "file or folder")
 	}
 
+	if s.IsFlat() {
+		if err := s.renameFlat(*current, updated); err != nil {
+			return err
+		}
+
+		s.forgetMirrored(current.Title)
+		return nil
+	}
+
 	if err := s.mv(models.EditNode{Current: *current, New: updated}); err != nil {
 		return err
 	}
@@ -397,7 +488,7 @@ func (s *FirebaseService) Rename(editNode models.EditNode) error {
 	if current.IsFolder() || updated.IsFolder() {
 		_, sub := s.GenerateDoc(nil, *current)
 
-		nodes, _, err := s.ListDir(sub, "", []string{}, 0)
+		nodes, _, err := s.listDir(sub, "", nil, 0)
 		if err != nil {
 			// TODO: shouldn't cut the whole action for one error.
 			return err
@@ -419,6 +510,7 @@ func (s *FirebaseService) Rename(editNode models.EditNode) error {
 		}
 	}
 
+	s.forgetMirrored(current.Title)
 	return nil
 }
 
@@ -436,7 +528,11 @@ func (s *FirebaseService) mv(editNode models.EditNode) error {
 		}
 	}
 
-	return s.Remove(editNode.Current)
+	// Only the document itself is removed, sub nodes are moved separately by [Rename].
+	doc, _ := s.GenerateDoc(nil, editNode.Current)
+	err := s.deleteDoc(doc)
+
+	return err
 }
 
 // ClearNodes removes all nodes from collection.
@@ -474,6 +570,16 @@ func (s *FirebaseService) ClearNodes() ([]models.Node, []error) {
 // @param additional path, [typ] that is allowed to fetch, and ignore list.
 // @returns an array of all nodes, titles of nodes and error if something went wrong.
 func (s *FirebaseService) GetAll(additional, typ string, ignore []string) ([]models.Node, []string, error) {
+	ignore = ignorePatterns(s.Config, ignore)
+
+	if s.Offline {
+		return s.listMirror(additional, typ, ignore)
+	}
+
+	if s.IsFlat() {
+		return s.listFlat(additional, typ, pkg.ParseIgnore("", ignore))
+	}
+
 	collection := s.NotyaCollection()
 	if len(additional) > 0 {
 		_, c := s.GenerateDoc(&collection, models.Node{Title: additional})
@@ -485,54 +591,212 @@ func (s *FirebaseService) GetAll(additional, typ string, ignore []string) ([]mod
 	return s.ListDir(&collection, typ, ignore, 0)
 }
 
+// GetChanged fetches all documents(including sub documents) that were modified after [since].
+//
+// Documents are queried by their [updated_at] field, so only changed documents are read.
+// Documents without [updated_at] (written by older versions of nt) aren't matched by the
+// query, run doctor with --fix to backfill it.
+//
+// If [since] is zero or querying by modification time isn't possible (e.g. collection group
+// index isn't enabled), it falls back to a full scan of the collection, and the latter case
+// is reported via [s.Warnings].
+func (s *FirebaseService) GetChanged(since time.Time, ignore []string) ([]models.Node, time.Time, error) {
+	if since.IsZero() {
+		return s.fullScan(ignore)
+	}
+
+	collection := s.NotyaCollection()
+	queries := []firestore.Query{collection.Where("updated_at", ">", since)}
+	if !s.IsFlat() {
+		queries = append(queries, s.FireStore.CollectionGroup("sub").Where("updated_at", ">", since))
+	}
+
+	mark := since
+	res := []models.Node{}
+	rules := pkg.ParseIgnore("", ignorePatterns(s.Config, ignore))
+	for _, query := range queries {
+		docs, err := s.queryDocs(query)
+		if err != nil {
+			s.Warnings = append(s.Warnings, assets.ChangesQueryFailed(err).Error())
+			return s.fullScan(ignore)
+		}
+
+		for _, doc := range docs {
+			// Collection group query includes documents of all
+			// collections, so documents out of nt collection must be skipped.
+			if !strings.HasPrefix(doc.Ref.Path, collection.Path+"/") {
+				continue
+			}
+
+			node, ok := DecodeDoc(doc.Data())
+			if !ok || rules.Match(node.Title, node.IsFolder()) {
+				continue
+			}
+
+			node.UpdatedAt = doc.UpdateTime
+
+			level := strings.Count(strings.Trim(node.Title, "/"), "/")
+			node.Pretty = []string{strings.Repeat("  ", level) + node.GenPretty(), path.Base(node.Title)}
+
+			if node.UpdatedAt.After(mark) {
+				mark = node.UpdatedAt
+			}
+
+			res = append(res, node)
+		}
+	}
+
+	return res, mark, nil
+}
+
+// fullScan is a sub implementation of [GetChanged].
+// Which lists all nodes of collection and calculates the high-water mark from them.
+func (s *FirebaseService) fullScan(ignore []string) ([]models.Node, time.Time, error) {
+	nodes, _, err := s.GetAll("", "", ignore)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var mark time.Time
+	for _, node := range nodes {
+		if node.UpdatedAt.After(mark) {
+			mark = node.UpdatedAt
+		}
+	}
+
+	return nodes, mark, nil
+}
+
+// GetHashes collects content hashes of all documents(including sub documents).
+// Only the metadata fields of documents are requested, so bodies aren't transferred.
+func (s *FirebaseService) GetHashes(ignore []string) (map[string]string, error) {
+	nodes, err := s.GetNodes(ignore)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := map[string]string{}
+	for key, node := range nodes {
+		hashes[key] = node.Hash
+	}
+
+	return hashes, nil
+}
+
+// GetNodes collects type, title and content hash of all documents(including sub documents).
+// Only the metadata fields of documents are requested, so bodies aren't transferred.
+func (s *FirebaseService) GetNodes(ignore []string) (map[string]models.Node, error) {
+	nodes := map[string]models.Node{}
+
+	collection := s.NotyaCollection()
+	rules := pkg.ParseIgnore("", ignorePatterns(s.Config, ignore))
+	if err := s.collectNodes(&collection, rules, nodes); err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
+// collectNodes is a sub implementation of [GetNodes].
+// Which fills [nodes] with the metadata of documents at [path], recursively.
+func (s *FirebaseService) collectNodes(path *firestore.CollectionRef, rules pkg.IgnoreRules, nodes map[string]models.Node) error {
+	if s.IsFlat() {
+		return s.collectFlatNodes(rules, nodes)
+	}
+
+	docs, err := s.queryDocs(path.Select("typ", "title", "hash"))
+	if err != nil {
+		return err
+	}
+
+	for _, doc := range docs {
+		node, ok := DecodeDoc(doc.Data())
+		if !ok || rules.Match(node.Title, node.IsFolder()) {
+			continue
+		}
+
+		nodes[HashKey(node.Title)] = node
+
+		if node.IsFolder() {
+			subPath := path.Doc(doc.Ref.ID).Collection("sub")
+			if err := s.collectNodes(subPath, rules, nodes); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 // ListDir retrieves the documents and sub-collections from a specified Firebase CollectionRef.
 //
 // @param {firestore.CollectionRef} path - The Firebase CollectionRef to retrieve documents and sub-collections from.
 // @param {string} typ - The type of documents to retrieve.
-// @param {[]string} ignore - An array of sub-collection names to ignore.
+// @param {[]string} ignore - An array of gitignore-style patterns of nodes to ignore.
 // @param {int} level - The number of levels deep to retrieve sub-collections.
 //
+// Rules of ignore file documents(see [models.IgnoreFileName]) are honored too, for their folders.
+//
 // @returns {[]models.Node, []string, error} A tuple containing an array of retrieved documents
 // and sub-collections (models.Node), an array of ignored sub-collection names, and an error if one occurred.
 func (s *FirebaseService) ListDir(path *firestore.CollectionRef, typ string, ignore []string, level int) ([]models.Node, []string, error) {
+	return s.listDir(path, typ, pkg.ParseIgnore("", ignore), level)
+}
+
+// listDir is the recursive implementation of [ListDir].
+// Nil [rules] lists all documents, without reading ignore files.
+func (s *FirebaseService) listDir(path *firestore.CollectionRef, typ string, rules pkg.IgnoreRules, level int) ([]models.Node, []string, error) {
 	var res []models.Node
 	var titles []string
 
-	iter := path.Documents(s.Ctx)
-	defer iter.Stop()
+	docs, err := s.queryDocs(path.Query)
+	if err != nil {
+		return res, titles, err
+	}
 
-	for {
-		doc, err := iter.Next()
-		if err == iterator.Done {
-			break
+	// Decode data to nodes, and keep the document IDs of them.
+	nodes, ids := []models.Node{}, []string{}
+	for _, doc := range docs {
+		node, ok := DecodeDoc(doc.Data())
+		if !ok {
+			continue
 		}
 
-		if err != nil {
-			return res, titles, err
+		if node.UpdatedAt.IsZero() {
+			node.UpdatedAt = doc.UpdateTime
 		}
 
+		if node.CreatedAt.IsZero() {
+			node.CreatedAt = doc.CreateTime
+		}
+
+		nodes, ids = append(nodes, node), append(ids, doc.Ref.ID)
+	}
+
+	if rules != nil {
+		rules = append(append(pkg.IgnoreRules{}, rules...), nodeIgnoreRules(nodes)...)
+	}
+
+	for i, node := range nodes {
 		// Ignore the current document, if it is ignorable.
-		if pkg.IsIgnorable(doc.Ref.ID, ignore) {
+		if rules.Match(node.Title, node.IsFolder()) {
 			continue
 		}
 
-		// Decode data to node
-		var node models.Node
-		node.FromJson(doc.Data())
-
 		if pkg.IsType(typ, node.IsFolder()) {
-			node.Pretty = []string{strings.Repeat("  ", level) + node.GenPretty(), doc.Ref.ID}
+			node.Pretty = []string{strings.Repeat("  ", level) + node.GenPretty(), ids[i]}
 
 			res = append(res, node)
 			titles = append(titles, node.Title)
 		}
 
 		if node.IsFolder() {
-			subPath := path.Doc(doc.Ref.ID).Collection("sub")
-			sub, subTitles, err := s.ListDir(subPath, typ, ignore, level+1)
+			subPath := path.Doc(ids[i]).Collection("sub")
+			sub, subTitles, err := s.listDir(subPath, typ, rules, level+1)
 			if err != nil {
-				// TODO: find a way of effective way of handling error
-				continue
+				// Transient errors are already retried, so the folder
+				// mustn't be dropped silently from the result.
+				return res, titles, assets.CannotDoSth("list", node.Title, err)
 			}
 
 			if len(sub) > 0 {
@@ -549,13 +813,21 @@ func (s *FirebaseService) ListDir(path *firestore.CollectionRef, typ string, ign
 // If a node(file or folder) already exists at provided note's path,
 // it will return already formatted error message.
 func (s *FirebaseService) Create(note models.Note) (*models.Note, error) {
+	if s.Offline {
+		if _, exists := s.expectedNodes()[HashKey(note.Title)]; exists {
+			return nil, assets.AlreadyExists(note.Title, "file")
+		}
+
+		return &note, s.enqueue(models.Operation{Kind: models.CreateOperation, Title: note.Title, Body: note.Body})
+	}
+
 	noteNode := note.ToNode()
 
 	path, _ := s.GeneratePath(nil, noteNode)
 	noteNode.UpdatePath(s.Type(), path)
 
 	noteDoc, _ := s.GenerateDoc(nil, noteNode)
-	if _, err := noteDoc.Create(s.Ctx, noteNode.ToJSON()); err != nil {
+	if err := s.createDoc(noteDoc, s.GenerateData(noteNode)); err != nil {
 		if status, ok := status.FromError(err); ok && status.Code() == codes.AlreadyExists {
 			return nil, assets.AlreadyExists(noteNode.Title, "file")
 		}
@@ -571,13 +843,17 @@ func (s *FirebaseService) Create(note models.Note) (*models.Note, error) {
 // If a node doesn't exists at provided note's path,
 // it will return a already formatted error message.
 func (s *FirebaseService) View(note models.Note) (*models.Note, error) {
+	if s.Offline {
+		return s.viewMirror(note)
+	}
+
 	noteNode := note.ToNode()
 
 	path, _ := s.GeneratePath(nil, noteNode)
 	noteNode.UpdatePath(s.Type(), path)
 
 	noteDoc, _ := s.GenerateDoc(nil, noteNode)
-	docSnapshot, err := noteDoc.Get(s.Ctx)
+	docSnapshot, err := s.fetchDoc(noteDoc)
 
 	if err != nil {
 		if status, ok := status.FromError(err); ok && status.Code() == codes.NotFound {
@@ -589,6 +865,17 @@ func (s *FirebaseService) View(note models.Note) (*models.Note, error) {
 
 	var model models.Note
 	mapstructure.Decode(docSnapshot.Data(), &model)
+	if len(model.Hash) == 0 {
+		model.Hash = pkg.Hash(model.Body)
+	}
+
+	model.UpdatedAt = docSnapshot.UpdateTime
+	model.CreatedAt = docSnapshot.CreateTime
+	if createdAt, ok := docSnapshot.Data()["created_at"].(time.Time); ok {
+		model.CreatedAt = createdAt
+	}
+
+	model.Meta, _ = models.ParseFrontMatter(model.Body)
 
 	return &model, nil
 }
@@ -596,16 +883,49 @@ func (s *FirebaseService) View(note models.Note) (*models.Note, error) {
 // Edit, updates the already created note, with locally updated note data.
 // If a node doesn't exists at provided note's path,
 // it will return a already formatted error message.
+//
+// If [note.UpdatedAt] is provided, document is updated only if it wasn't
+// changed after that time. Otherwise, [assets.EditConflict] is returned.
 func (s *FirebaseService) Edit(note models.Note) (*models.Note, error) {
+	if s.Offline {
+		return &note, s.enqueue(models.Operation{
+			Kind:     models.EditOperation,
+			Title:    note.Title,
+			Body:     note.Body,
+			BaseHash: s.baseHash(note.Title),
+			BaseTime: note.UpdatedAt,
+		})
+	}
+
 	noteNode := note.ToNode()
 
 	path, _ := s.GeneratePath(nil, noteNode)
 	noteNode.UpdatePath(s.Type(), path)
 
 	noteDoc, _ := s.GenerateDoc(nil, noteNode)
-	if _, err := noteDoc.Set(s.Ctx, noteNode.ToJSON()); err != nil {
+	data := s.GenerateData(noteNode)
+
+	// Creation time of document is never updated, since local notes
+	// provide the birth time of their file instead of the remote one.
+	delete(data, "created_at")
+
+	var err error
+	if note.UpdatedAt.IsZero() {
+		err = s.setDoc(noteDoc, data, firestore.MergeAll)
+	} else {
+		updates := []firestore.Update{}
+		for key, value := range data {
+			updates = append(updates, firestore.Update{Path: key, Value: value})
+		}
+
+		err = s.updateDoc(noteDoc, updates, firestore.LastUpdateTime(note.UpdatedAt))
+	}
+
+	if err != nil {
 		if status, ok := status.FromError(err); ok && status.Code() == codes.NotFound {
 			return nil, assets.NotExists(path, "File")
+		} else if ok && status.Code() == codes.FailedPrecondition {
+			return nil, assets.EditConflict
 		}
 
 		return nil, err
@@ -637,10 +957,11 @@ func (s *FirebaseService) Cut(note models.Note) (*models.Note, error) {
 	}
 
 	doc, _ := s.GenerateDoc(nil, note.ToNode())
-	if _, err := doc.Delete(s.Ctx); err != nil {
+	if err := s.deleteDoc(doc); err != nil {
 		return nil, err
 	}
 
+	s.forgetMirrored(note.Title)
 	return n, nil
 }
 
@@ -649,13 +970,21 @@ func (s *FirebaseService) Cut(note models.Note) (*models.Note, error) {
 // that sub collection gonna represent the files/folders that current
 // directory includes.
 func (s *FirebaseService) Mkdir(dir models.Folder) (*models.Folder, error) {
+	if s.Offline {
+		if _, exists := s.expectedNodes()[HashKey(dir.Title)]; exists {
+			return nil, assets.AlreadyExists(dir.Title, "folder")
+		}
+
+		return &dir, s.enqueue(models.Operation{Kind: models.MkdirOperation, Type: models.FOLDER, Title: dir.Title})
+	}
+
 	dirNode := dir.ToNode()
 
 	path, _ := s.GeneratePath(nil, dirNode)
 	dirNode.UpdatePath(s.Type(), path)
 
 	folderDoc, _ := s.GenerateDoc(nil, dirNode)
-	if _, err := folderDoc.Create(s.Ctx, dirNode.ToJSON()); err != nil {
+	if err := s.createDoc(folderDoc, s.GenerateData(dirNode)); err != nil {
 		if status, ok := status.FromError(err); ok && status.Code() == codes.AlreadyExists {
 			return nil, assets.AlreadyExists(dirNode.Title, "folder")
 		}
@@ -676,27 +1005,69 @@ func (s *FirebaseService) MoveNotes(settings models.Settings) error {
 	}
 
 	prevSettings := s.Config
+	moved, couldntMoved := []models.Node{}, []models.Node{}
+
+	// Create nodes appropriate by updated settings.
+	s.Config.FirebaseCollection = settings.FirebaseCollection
 	for _, node := range nodes {
-		// Remove note appropriate by default settings
-		s.Config.FirebaseCollection = prevSettings.FirebaseCollection
-		if err := s.Remove(node); err != nil {
-			continue
+		n := node
+		n.Path = nil // path of previous collection shouldn't be used.
+
+		var err error
+		if n.IsFolder() {
+			_, err = s.Mkdir(n.ToFolder())
+		} else {
+			_, err = s.Create(n.ToNote())
 		}
 
-		// Create note appropriate by updated settings
-		s.Config.FirebaseCollection = settings.FirebaseCollection
-		if _, err := s.Create(node.ToNote()); err != nil {
+		if err != nil {
+			couldntMoved = append(couldntMoved, node)
 			continue
 		}
+
+		moved = append(moved, node)
+	}
+
+	// Remove moved nodes appropriate by previous settings.
+	// Folders are removed with their sub nodes, so deepest nodes must be removed first.
+	s.Config.FirebaseCollection = prevSettings.FirebaseCollection
+	sort.Slice(
+		moved,
+		func(i, j int) bool { return len(moved[i].Title) > len(moved[j].Title) },
+	)
+
+	for _, node := range moved {
+		// If a sub node of folder couldn't moved, folder shouldn't be removed.
+		keep := false
+		for _, c := range couldntMoved {
+			keep = keep || (node.IsFolder() && strings.HasPrefix(c.Title, node.ToFolder().Title))
+		}
+
+		if !keep {
+			_ = s.Remove(node)
+		}
 	}
 
+	s.Config.FirebaseCollection = settings.FirebaseCollection
+
 	return nil
 }
 
 // Fetch creates a clone of nodes(that doesn't exists on
 // [s](firebase-service)) from given [remote] service.
-func (s *FirebaseService) Fetch(remote ServiceRepo) ([]models.Node, []error) {
-	nodes, _, err := remote.GetAll("", "", models.NotyaIgnoreFiles)
+func (s *FirebaseService) Fetch(remote ServiceRepo, patterns []string) ([]models.Node, []error) {
+	// Fetching from local service is pushing local changes, which is done by
+	// local service, since it keeps the hashes of synced nodes. See [LocalService.Push].
+	if local, ok := remote.(*LocalService); ok {
+		return local.Push(s, patterns)
+	}
+
+	nodes, err := ListSelected(remote, patterns, models.NotyaIgnoreFiles)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	hashes, err := s.GetHashes(models.NotyaIgnoreFiles)
 	if err != nil {
 		return nil, []error{err}
 	}
@@ -705,17 +1076,10 @@ func (s *FirebaseService) Fetch(remote ServiceRepo) ([]models.Node, []error) {
 	errors := []error{}
 
 	for _, node := range nodes {
-		exists, _ := s.IsNodeExists(node)
+		currentHash, exists := hashes[HashKey(node.Title)]
 		if exists && !node.IsFolder() {
-			local, err := s.View(node.ToNote())
-			if err != nil {
-				errors = append(errors, assets.CannotDoSth("fetch", node.Title, err))
-				continue
-			}
-
-			if local.Body != node.Body {
-				local.Body = node.Body
-				if _, err := s.Edit(*local); err != nil {
+			if currentHash != pkg.Hash(node.Body) {
+				if _, err := s.Edit(models.Note{Title: node.Title, Body: node.Body}); err != nil {
 					errors = append(errors, assets.CannotDoSth("fetch", node.Title, err))
 					continue
 				}
@@ -751,8 +1115,18 @@ func (s *FirebaseService) Fetch(remote ServiceRepo) ([]models.Node, []error) {
 }
 
 // Push uploads nodes(that doesn't exists on given remote) from [s](current) to given [remote].
-func (s *FirebaseService) Push(remote ServiceRepo) ([]models.Node, []error) {
-	nodes, _, err := s.GetAll("", "", models.NotyaIgnoreFiles)
+func (s *FirebaseService) Push(remote ServiceRepo, patterns []string) ([]models.Node, []error) {
+	// Pushing to local service is fetching remote changes. See [LocalService.Fetch].
+	if local, ok := remote.(*LocalService); ok {
+		return local.Fetch(s, patterns)
+	}
+
+	nodes, err := ListSelected(s, patterns, models.NotyaIgnoreFiles)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	hashes, err := remote.GetHashes(models.NotyaIgnoreFiles)
 	if err != nil {
 		return nil, []error{err}
 	}
@@ -761,9 +1135,13 @@ func (s *FirebaseService) Push(remote ServiceRepo) ([]models.Node, []error) {
 	pushed := []models.Node{}
 
 	for _, node := range nodes {
-		exists, _ := remote.IsNodeExists(node)
+		remoteHash, exists := hashes[HashKey(node.Title)]
+
+		if node.IsFolder() {
+			if exists {
+				continue
+			}
 
-		if node.IsFolder() && !exists {
 			if _, err := remote.Mkdir(node.ToFolder()); err != nil {
 				errors = append(errors, assets.CannotDoSth("push", node.Title, err))
 			} else {
@@ -773,7 +1151,6 @@ func (s *FirebaseService) Push(remote ServiceRepo) ([]models.Node, []error) {
 			continue
 		}
 
-		r, _ := remote.View(node.ToNote())
 		if !exists {
 			if _, err := remote.Create(node.ToNote()); err != nil {
 				errors = append(errors, assets.CannotDoSth("push", node.Title, err))
@@ -784,7 +1161,7 @@ func (s *FirebaseService) Push(remote ServiceRepo) ([]models.Node, []error) {
 			continue
 		}
 
-		if r.Body != node.Body {
+		if remoteHash != pkg.Hash(node.Body) {
 			if _, err := remote.Edit(node.ToNote()); err != nil {
 				errors = append(errors, assets.CannotDoSth("push", node.Title, err))
 			} else {
@@ -797,10 +1174,10 @@ func (s *FirebaseService) Push(remote ServiceRepo) ([]models.Node, []error) {
 }
 
 // Migrate overwrites all notes of given [remote] service with [s](firebase-service).
-func (s *FirebaseService) Migrate(remote ServiceRepo) ([]models.Node, []error) {
-	if _, err := remote.ClearNodes(); err != nil {
+func (s *FirebaseService) Migrate(remote ServiceRepo, patterns []string) ([]models.Node, []error) {
+	if _, err := ClearSelected(remote, patterns); err != nil {
 		return nil, err
 	}
 
-	return s.Push(remote)
+	return s.Push(remote, patterns)
 }
@@ -0,0 +1,41 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/lib/services"
+)
+
+func TestDecodeDoc(t *testing.T) {
+	settings := models.Settings{Name: "nt", Editor: "vi", NotesPath: "/home/user/nt"}
+
+	// Documents of a nested collection, where settings document is kept with nodes.
+	docs := []map[string]interface{}{
+		settings.ToJSON(),
+		{"typ": "FOLDER", "title": "work/"},
+		{"typ": "FILE", "title": "work/todo.md", "body": "todo"},
+		{"typ": "FILE", "title": "/"},
+		{"typ": "FILE", "title": 42},
+	}
+
+	expected := []string{"work/", "work/todo.md"}
+
+	got := []string{}
+	for _, doc := range docs {
+		if node, ok := services.DecodeDoc(doc); ok {
+			got = append(got, node.Title)
+		}
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("DecodeDoc sum is different, Want: %v | Got: %v", expected, got)
+	}
+}
@@ -0,0 +1,216 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services
+
+import (
+	"context"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/insolite-dev/nt/assets"
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/pkg"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Subscribe listens to the changes of notes collection(including "sub" collections)
+// in realtime, and streams them as events, until [ctx] is done.
+//
+// Nodes that already exist at the time of subscription aren't streamed, only
+// the changes made after it. Failures of listeners are sent to the error channel.
+// Both channels are closed, after all listeners are stopped.
+func (s *FirebaseService) Subscribe(ctx context.Context, ignore []string) (<-chan models.Event, <-chan error) {
+	collection := s.NotyaCollection()
+
+	queries := []firestore.Query{collection.Query}
+	if !s.IsFlat() {
+		queries = append(queries, s.FireStore.CollectionGroup("sub").Query)
+	}
+
+	events := make(chan models.Event)
+	errs := make(chan error, len(queries))
+
+	if s.Offline {
+		errs <- assets.CannotWatchOffline
+		close(events)
+		close(errs)
+
+		return events, errs
+	}
+
+	rules := pkg.ParseIgnore("", ignorePatterns(s.Config, ignore))
+
+	var wg sync.WaitGroup
+	for _, query := range queries {
+		wg.Add(1)
+		go func(q firestore.Query) {
+			defer wg.Done()
+			s.listen(ctx, q, rules, events, errs)
+		}(query)
+	}
+
+	go func() {
+		wg.Wait()
+		close(events)
+		close(errs)
+	}()
+
+	return events, errs
+}
+
+// listen is a sub implementation of [Subscribe].
+// Which converts snapshot changes of [query] to events, and sends them to [events].
+func (s *FirebaseService) listen(ctx context.Context, query firestore.Query, rules pkg.IgnoreRules, events chan<- models.Event, errs chan<- error) {
+	it := query.Snapshots(ctx)
+	defer it.Stop()
+
+	initial := true
+	for {
+		snap, err := it.Next()
+		if err != nil {
+			if ctx.Err() == nil && status.Code(err) != codes.Canceled {
+				errs <- err
+			}
+
+			return
+		}
+
+		// The first snapshot contains the current state of collection.
+		if initial {
+			initial = false
+			continue
+		}
+
+		for _, change := range snap.Changes {
+			event, ok := s.toEvent(change, snap.ReadTime, rules)
+			if !ok {
+				continue
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// toEvent converts document change to event.
+// Returns false, if the document is out of notes collection, or ignorable.
+func (s *FirebaseService) toEvent(change firestore.DocumentChange, readTime time.Time, rules pkg.IgnoreRules) (models.Event, bool) {
+	collection := s.NotyaCollection()
+	doc := change.Doc
+
+	// Collection group listener observes documents of all
+	// collections, so documents out of nt collection must be skipped.
+	if !strings.HasPrefix(doc.Ref.Path, collection.Path+"/") {
+		return models.Event{}, false
+	}
+
+	node, ok := DecodeDoc(doc.Data())
+	if !ok {
+		return models.Event{}, false
+	}
+
+	if rules.Match(node.Title, node.IsFolder()) {
+		return models.Event{}, false
+	}
+
+	event := models.Event{
+		Service: s.Type(),
+		Path:    collection.ID + strings.TrimPrefix(doc.Ref.Path, collection.Path),
+		Time:    doc.UpdateTime,
+	}
+
+	switch change.Kind {
+	case firestore.DocumentAdded:
+		event.Kind = models.CreatedEvent
+	case firestore.DocumentModified:
+		event.Kind = models.UpdatedEvent
+	case firestore.DocumentRemoved:
+		event.Kind = models.RemovedEvent
+		event.Time = readTime
+	}
+
+	node.UpdatedAt = doc.UpdateTime
+	event.Node = node
+
+	return event, true
+}
+
+// ApplyEvent applies the remote change of [event] to [local] service.
+// Removed nodes are deleted, created or updated folders are made,
+// and created or updated notes are written (with their parent folders).
+// Changes of nodes, that're ignored at local service are skipped.
+func ApplyEvent(local ServiceRepo, event models.Event) error {
+	node := models.Node{Type: event.Node.Type, Title: event.Node.Title}
+	if l, ok := local.(*LocalService); ok && l.IsIgnored(node.Title, node.IsFolder()) {
+		return nil
+	}
+
+	exists, _ := local.IsNodeExists(node)
+
+	if event.Kind == models.RemovedEvent {
+		if !exists {
+			return nil
+		}
+
+		return local.Remove(node)
+	}
+
+	if err := ensureParents(local, node.Title); err != nil {
+		return err
+	}
+
+	if node.IsFolder() {
+		if exists {
+			return nil
+		}
+
+		_, err := local.Mkdir(node.ToFolder())
+		return err
+	}
+
+	note := models.Note{Title: node.Title, Body: event.Node.Body}
+	if !exists {
+		_, err := local.Create(note)
+		return err
+	}
+
+	current, err := local.View(note)
+	if err == nil && current.Hash == pkg.Hash(note.Body) {
+		return nil
+	}
+
+	_, err = local.Edit(note)
+	return err
+}
+
+// ensureParents makes the missing parent folders of [title] at [local] service.
+func ensureParents(local ServiceRepo, title string) error {
+	parent := path.Dir(strings.Trim(title, "/"))
+	if parent == "." || parent == "/" {
+		return nil
+	}
+
+	folder := models.Folder{Title: parent + "/"}
+	if exists, _ := local.IsNodeExists(folder.ToNode()); exists {
+		return nil
+	}
+
+	if err := ensureParents(local, parent); err != nil {
+		return err
+	}
+
+	_, err := local.Mkdir(folder)
+	return err
+}
@@ -0,0 +1,354 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/pkg"
+)
+
+// LinkIndex resolves links between notes, by the titles of notes.
+// Titles are the paths relative to the root of notes(see [LocalService.GeneratePath]),
+// so links are resolved the same way for all services.
+//
+// Resolution is case-insensitive, and extensions of notes could be omitted:
+//   - wiki link is looked up from the root, then from the folder of linking note,
+//     and at last by its name in any folder(the shortest path wins).
+//   - markdown link is relative to the folder of linking note, or to the root if it starts with "/".
+type LinkIndex struct {
+	// titles maps lowercase titles(with and without extension) to titles of notes.
+	titles map[string][]string
+
+	// names maps lowercase names(with and without extension) to titles of notes.
+	names map[string][]string
+}
+
+// NewLinkIndex generates a link index from the notes of [nodes].
+func NewLinkIndex(nodes []models.Node) *LinkIndex {
+	index := &LinkIndex{titles: map[string][]string{}, names: map[string][]string{}}
+
+	for _, node := range nodes {
+		if !node.IsFile() {
+			continue
+		}
+
+		title := HashKey(node.Title)
+		name := path.Base(title)
+
+		for _, key := range linkKeys(title) {
+			index.titles[key] = append(index.titles[key], title)
+		}
+
+		for _, key := range linkKeys(name) {
+			index.names[key] = append(index.names[key], title)
+		}
+	}
+
+	for _, m := range []map[string][]string{index.titles, index.names} {
+		for key := range m {
+			sortTitles(m[key])
+		}
+	}
+
+	return index
+}
+
+// linkKeys generates the lookup keys of title, with and without its extension.
+func linkKeys(title string) []string {
+	key := strings.ToLower(title)
+	if ext := path.Ext(key); len(ext) > 0 && ext != key {
+		return []string{key, strings.TrimSuffix(key, ext)}
+	}
+
+	return []string{key}
+}
+
+// sortTitles sorts titles by their depth and then alphabetically,
+// to prefer the shortest path at ambiguous links.
+func sortTitles(titles []string) {
+	sort.Slice(titles, func(i, j int) bool {
+		di, dj := strings.Count(titles[i], "/"), strings.Count(titles[j], "/")
+		if di != dj {
+			return di < dj
+		}
+
+		return titles[i] < titles[j]
+	})
+}
+
+// lookup returns the first title of [key] at given map.
+func lookup(m map[string][]string, key string) string {
+	key = strings.ToLower(strings.Trim(path.Clean("/"+key), "/"))
+	if titles := m[key]; len(titles) > 0 {
+		return titles[0]
+	}
+
+	return ""
+}
+
+// Resolve returns the title of note that [link] refers to.
+// Empty result means the link is dangling.
+func (x *LinkIndex) Resolve(link models.Link) string {
+	target := strings.TrimSpace(link.Target)
+	dir := path.Dir(HashKey(link.Source))
+
+	if link.Kind == models.MarkdownLink {
+		if strings.HasPrefix(target, "/") {
+			return lookup(x.titles, target)
+		}
+
+		return lookup(x.titles, path.Join(dir, target))
+	}
+
+	if title := lookup(x.titles, target); len(title) > 0 {
+		return title
+	}
+
+	if title := lookup(x.titles, path.Join(dir, target)); len(title) > 0 {
+		return title
+	}
+
+	if strings.Contains(strings.Trim(target, "/"), "/") {
+		return ""
+	}
+
+	return lookup(x.names, target)
+}
+
+// Links parses and resolves the outgoing links of [node].
+func (x *LinkIndex) Links(node models.Node) []models.Link {
+	if !node.IsFile() {
+		return nil
+	}
+
+	links := models.ParseLinks(HashKey(node.Title), node.Body)
+	for i := range links {
+		links[i].Title = x.Resolve(links[i])
+	}
+
+	return links
+}
+
+// AllLinks parses and resolves the outgoing links of all notes at [nodes].
+func (x *LinkIndex) AllLinks(nodes []models.Node) []models.Link {
+	links := []models.Link{}
+	for _, node := range nodes {
+		links = append(links, x.Links(node)...)
+	}
+
+	return links
+}
+
+// Backlinks collects the links of [nodes] that refer to the note of [title].
+func (x *LinkIndex) Backlinks(nodes []models.Node, title string) []models.Link {
+	title = HashKey(title)
+
+	backlinks := []models.Link{}
+	for _, link := range x.AllLinks(nodes) {
+		if link.Title == title {
+			backlinks = append(backlinks, link)
+		}
+	}
+
+	return backlinks
+}
+
+// DanglingLinks collects the links of [nodes] that don't refer to an existing note.
+func (x *LinkIndex) DanglingLinks(nodes []models.Node) []models.Link {
+	dangling := []models.Link{}
+	for _, link := range x.AllLinks(nodes) {
+		if link.IsDangling() {
+			dangling = append(dangling, link)
+		}
+	}
+
+	return dangling
+}
+
+// DiagnoseLinks adds the dangling links of [service] notes to the [report].
+// Dangling links could be fixed only by editing, so they're only reported.
+// Templates are skipped, since their links are placeholders(see [models.NoteIgnoreFiles]).
+// Problems of listing notes are diagnosed by the doctor of service, so they're skipped here.
+func DiagnoseLinks(service ServiceRepo, report *models.DoctorReport) {
+	nodes, _, err := service.GetAll("", "file", models.NoteIgnoreFiles)
+	if err != nil {
+		return
+	}
+
+	for _, link := range NewLinkIndex(nodes).DanglingLinks(nodes) {
+		report.Add(models.Diagnosis{
+			Kind:       models.DanglingLinkDiagnosis,
+			Path:       fmt.Sprintf("%v:%v", link.Source, link.Line),
+			Message:    fmt.Sprintf("Link to %q doesn't refer to an existing note", link.Target),
+			Suggestion: "Create the note, or edit the link",
+		})
+	}
+}
+
+// RenameLinks rewrites the links of [nodes] that would be broken by the renaming of [edit].
+// Renaming could be of a note or a folder, so links to the notes of renamed folder
+// and relative markdown links of moved notes are rewritten too.
+//
+// Rewrites are titled by the titles of notes after renaming, and bodies aren't saved here.
+// Hashes and modification times of notes are kept, to save rewrites only over unchanged notes.
+func (x *LinkIndex) RenameLinks(nodes []models.Node, edit models.EditNode) []models.LinkRewrite {
+	from, to := HashKey(edit.Current.Title), HashKey(edit.New.Title)
+
+	// moved returns the title of [title] after renaming.
+	moved := func(title string) (string, bool) {
+		if title == from {
+			return to, true
+		}
+
+		if strings.HasPrefix(title, from+"/") {
+			return to + strings.TrimPrefix(title, from), true
+		}
+
+		return title, false
+	}
+
+	// Generate the index of notes after renaming, to check if rewritten links resolve properly.
+	renamed := []models.Node{}
+	for _, node := range nodes {
+		node.Title, _ = moved(HashKey(node.Title))
+		renamed = append(renamed, node)
+	}
+	after := NewLinkIndex(renamed)
+
+	rewrites := []models.LinkRewrite{}
+	for _, node := range nodes {
+		if !node.IsFile() {
+			continue
+		}
+
+		source := HashKey(node.Title)
+		newSource, sourceMoved := moved(source)
+
+		changes := []models.LinkChange{}
+		body := models.RewriteLinks(source, node.Body, func(link models.Link) (string, bool) {
+			title := x.Resolve(link)
+			if len(title) == 0 {
+				return "", false
+			}
+
+			newTitle, targetMoved := moved(title)
+			if !targetMoved && (!sourceMoved || link.Kind != models.MarkdownLink) {
+				return "", false
+			}
+
+			target := renameTarget(link, title, newTitle, newSource, after)
+			if target == link.Target {
+				return "", false
+			}
+
+			changes = append(changes, models.LinkChange{Line: link.Line, From: link.Target, To: target})
+			return target, true
+		})
+
+		if len(changes) > 0 {
+			rewrite := models.LinkRewrite{Title: newSource, Body: body, Changes: changes, Hash: pkg.Hash(node.Body)}
+			if !sourceMoved {
+				rewrite.UpdatedAt = node.UpdatedAt
+			}
+
+			rewrites = append(rewrites, rewrite)
+		}
+	}
+
+	return rewrites
+}
+
+// renameTarget generates the target of [link] that refers to [newTitle] from [source],
+// in the same style as the link was written: with or without extension, by name or by path.
+func renameTarget(link models.Link, title, newTitle, source string, after *LinkIndex) string {
+	target := newTitle
+	if ext := path.Ext(title); len(ext) > 0 && !strings.HasSuffix(strings.ToLower(link.Target), strings.ToLower(ext)) {
+		target = strings.TrimSuffix(target, path.Ext(target))
+	}
+
+	if link.Kind == models.MarkdownLink {
+		if strings.HasPrefix(link.Target, "/") {
+			return "/" + target
+		}
+
+		return relativePath(path.Dir(source), target)
+	}
+
+	// Keep wiki links by name, as long as the name refers to the renamed note.
+	if !strings.Contains(strings.Trim(link.Target, "/"), "/") {
+		name := path.Base(target)
+		if after.Resolve(models.Link{Kind: models.WikiLink, Source: source, Target: name}) == newTitle {
+			return name
+		}
+	}
+
+	return target
+}
+
+// relativePath generates the path of [target] relative to the [dir].
+// Both of them are relative to the root of notes.
+func relativePath(dir, target string) string {
+	dirs := []string{}
+	if dir != "." && len(dir) > 0 {
+		dirs = strings.Split(dir, "/")
+	}
+
+	parts := strings.Split(target, "/")
+
+	common := 0
+	for common < len(dirs) && common < len(parts)-1 && dirs[common] == parts[common] {
+		common++
+	}
+
+	rel := []string{}
+	for range dirs[common:] {
+		rel = append(rel, "..")
+	}
+
+	return strings.Join(append(rel, parts[common:]...), "/")
+}
+
+// NewGraph generates the graph of [nodes], by the links of notes and containment of folders.
+// Dangling links and links of notes to themselves aren't included to the graph.
+func NewGraph(nodes []models.Node) *models.Graph {
+	graph := &models.Graph{Nodes: []models.GraphNode{}, Edges: []models.GraphEdge{}}
+
+	exists := map[string]bool{}
+	for _, node := range nodes {
+		id := HashKey(node.Title)
+		if len(id) == 0 || exists[id] {
+			continue
+		}
+
+		exists[id] = true
+		graph.Nodes = append(graph.Nodes, models.GraphNode{ID: id, Type: node.Type})
+	}
+
+	for _, node := range graph.Nodes {
+		if parent := path.Dir(node.ID); exists[parent] {
+			graph.Edges = append(graph.Edges, models.GraphEdge{From: parent, To: node.ID, Kind: models.ContainsEdge})
+		}
+	}
+
+	linked := map[[2]string]bool{}
+	for _, link := range NewLinkIndex(nodes).AllLinks(nodes) {
+		key := [2]string{link.Source, link.Title}
+		if link.IsDangling() || link.Source == link.Title || linked[key] {
+			continue
+		}
+
+		linked[key] = true
+		graph.Edges = append(graph.Edges, models.GraphEdge{From: link.Source, To: link.Title, Kind: models.LinkEdge})
+	}
+
+	return graph
+}
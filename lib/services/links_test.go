@@ -0,0 +1,204 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/lib/services"
+	"github.com/insolite-dev/nt/pkg"
+)
+
+func TestLinkIndex(t *testing.T) {
+	nodes := []models.Node{
+		{Type: models.FOLDER, Title: "work/"},
+		{Type: models.FILE, Title: "work/todo.md", Body: "Back to [[journal]]"},
+		{Type: models.FILE, Title: "work/plan.md", Body: "[[Todo]], [todo](todo.md), [root](/journal.md) and [[missing]]"},
+		{Type: models.FILE, Title: "archive/old/todo.md", Body: "[up](../../work/plan.md)"},
+		{Type: models.FILE, Title: "journal.md", Body: "[[todo]] [[work/plan]] [[old/todo]] [plan](work/plan.md#goals)"},
+	}
+
+	index := services.NewLinkIndex(nodes)
+
+	tests := []struct {
+		title    string
+		expected []string
+	}{
+		{title: "work/todo.md", expected: []string{"journal.md"}},
+		{title: "work/plan.md", expected: []string{"work/todo.md", "work/todo.md", "journal.md", ""}},
+		{title: "archive/old/todo.md", expected: []string{"work/plan.md"}},
+		{title: "journal.md", expected: []string{"work/todo.md", "work/plan.md", "", "work/plan.md"}},
+	}
+
+	for _, td := range tests {
+		var node models.Node
+		for _, n := range nodes {
+			if n.Title == td.title {
+				node = n
+			}
+		}
+
+		got := []string{}
+		for _, link := range index.Links(node) {
+			got = append(got, link.Title)
+		}
+
+		if !reflect.DeepEqual(got, td.expected) {
+			t.Errorf("Links of %v sum is different, Want: %v | Got: %v", td.title, td.expected, got)
+		}
+	}
+
+	backlinks := []string{}
+	for _, link := range index.Backlinks(nodes, "work/plan.md") {
+		backlinks = append(backlinks, link.Source)
+	}
+
+	if expected := []string{"archive/old/todo.md", "journal.md", "journal.md"}; !reflect.DeepEqual(backlinks, expected) {
+		t.Errorf("Backlinks sum is different, Want: %v | Got: %v", expected, backlinks)
+	}
+
+	dangling := []string{}
+	for _, link := range index.DanglingLinks(nodes) {
+		dangling = append(dangling, link.Target)
+	}
+
+	if expected := []string{"missing", "old/todo"}; !reflect.DeepEqual(dangling, expected) {
+		t.Errorf("DanglingLinks sum is different, Want: %v | Got: %v", expected, dangling)
+	}
+}
+
+func TestRenameLinks(t *testing.T) {
+	updatedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	nodes := []models.Node{
+		{Type: models.FILE, Title: "work/todo.md", Body: "Back to [journal](../journal.md) and [[journal]]", UpdatedAt: updatedAt},
+		{Type: models.FILE, Title: "work/plan.md", Body: "[[todo]], [[work/todo.md]] and [todo](todo.md#today)", UpdatedAt: updatedAt},
+		{Type: models.FILE, Title: "journal.md", Body: "[[todo]], [todo](work/todo) and [root](/work/todo.md)", UpdatedAt: updatedAt},
+		{Type: models.FILE, Title: "ideas.md", Body: "Nothing to [[rewrite]]", UpdatedAt: updatedAt},
+	}
+
+	tests := []struct {
+		testName string
+		edit     models.EditNode
+		expected []models.LinkRewrite
+	}{
+		{
+			testName: "should rewrite links to renamed note",
+			edit:     models.EditNode{Current: models.Node{Title: "work/todo.md"}, New: models.Node{Title: "work/tasks.md"}},
+			expected: []models.LinkRewrite{
+				{
+					Title:     "work/plan.md",
+					Body:      "[[tasks]], [[work/tasks.md]] and [todo](tasks.md#today)",
+					Hash:      pkg.Hash(nodes[1].Body),
+					UpdatedAt: updatedAt,
+					Changes: []models.LinkChange{
+						{Line: 1, From: "todo", To: "tasks"},
+						{Line: 1, From: "work/todo.md", To: "work/tasks.md"},
+						{Line: 1, From: "todo.md", To: "tasks.md"},
+					},
+				},
+				{
+					Title:     "journal.md",
+					Body:      "[[tasks]], [todo](work/tasks) and [root](/work/tasks.md)",
+					Hash:      pkg.Hash(nodes[2].Body),
+					UpdatedAt: updatedAt,
+					Changes: []models.LinkChange{
+						{Line: 1, From: "todo", To: "tasks"},
+						{Line: 1, From: "work/todo", To: "work/tasks"},
+						{Line: 1, From: "/work/todo.md", To: "/work/tasks.md"},
+					},
+				},
+			},
+		},
+		{
+			testName: "should rewrite links to and from renamed folder",
+			edit:     models.EditNode{Current: models.Node{Title: "work/"}, New: models.Node{Title: "archive/work"}},
+			expected: []models.LinkRewrite{
+				{
+					Title:   "archive/work/todo.md",
+					Body:    "Back to [journal](../../journal.md) and [[journal]]",
+					Hash:    pkg.Hash(nodes[0].Body),
+					Changes: []models.LinkChange{{Line: 1, From: "../journal.md", To: "../../journal.md"}},
+				},
+				{
+					Title:   "archive/work/plan.md",
+					Body:    "[[todo]], [[archive/work/todo.md]] and [todo](todo.md#today)",
+					Hash:    pkg.Hash(nodes[1].Body),
+					Changes: []models.LinkChange{{Line: 1, From: "work/todo.md", To: "archive/work/todo.md"}},
+				},
+				{
+					Title:     "journal.md",
+					Body:      "[[todo]], [todo](archive/work/todo) and [root](/archive/work/todo.md)",
+					Hash:      pkg.Hash(nodes[2].Body),
+					UpdatedAt: updatedAt,
+					Changes: []models.LinkChange{
+						{Line: 1, From: "work/todo", To: "archive/work/todo"},
+						{Line: 1, From: "/work/todo.md", To: "/archive/work/todo.md"},
+					},
+				},
+			},
+		},
+		{
+			testName: "should use full path if name becomes ambiguous",
+			edit:     models.EditNode{Current: models.Node{Title: "journal.md"}, New: models.Node{Title: "work/ideas.md"}},
+			expected: []models.LinkRewrite{
+				{
+					Title:     "work/todo.md",
+					Body:      "Back to [journal](ideas.md) and [[work/ideas]]",
+					Hash:      pkg.Hash(nodes[0].Body),
+					UpdatedAt: updatedAt,
+					Changes: []models.LinkChange{
+						{Line: 1, From: "../journal.md", To: "ideas.md"},
+						{Line: 1, From: "journal", To: "work/ideas"},
+					},
+				},
+				{
+					Title:   "work/ideas.md",
+					Body:    "[[todo]], [todo](todo) and [root](/work/todo.md)",
+					Hash:    pkg.Hash(nodes[2].Body),
+					Changes: []models.LinkChange{{Line: 1, From: "work/todo", To: "todo"}},
+				},
+			},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			got := services.NewLinkIndex(nodes).RenameLinks(nodes, td.edit)
+			if !reflect.DeepEqual(got, td.expected) {
+				t.Errorf("RenameLinks sum is different, Want: %v | Got: %v", td.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewGraph(t *testing.T) {
+	nodes := []models.Node{
+		{Type: models.FOLDER, Title: "work/"},
+		{Type: models.FILE, Title: "work/todo.md", Body: "[[journal]], [[journal]] and [[todo]]"},
+		{Type: models.FILE, Title: "journal.md", Body: "[[todo]] and [[missing]]"},
+	}
+
+	expected := &models.Graph{
+		Nodes: []models.GraphNode{
+			{ID: "work", Type: models.FOLDER},
+			{ID: "work/todo.md", Type: models.FILE},
+			{ID: "journal.md", Type: models.FILE},
+		},
+		Edges: []models.GraphEdge{
+			{From: "work", To: "work/todo.md", Kind: models.ContainsEdge},
+			{From: "work/todo.md", To: "journal.md", Kind: models.LinkEdge},
+			{From: "journal.md", To: "work/todo.md", Kind: models.LinkEdge},
+		},
+	}
+
+	if got := services.NewGraph(nodes); !reflect.DeepEqual(got, expected) {
+		t.Errorf("NewGraph sum is different, Want: %v | Got: %v", expected, got)
+	}
+}
@@ -0,0 +1,233 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/pkg"
+)
+
+// Doctor detects inconsistencies of local working directory:
+//   - missing or invalid fields of settings, non-existent notes path and unavailable editor.
+//   - unreadable files and folders.
+//   - broken symlinks and symlink loops.
+//   - files that are hidden from nt, only because of their names.
+//   - temporary notes left after a crash of remote editing.
+//
+// If [fix] is true, settings are completed with defaults, notes path is created,
+// unreadable files are made readable, broken symlinks and loops are removed.
+// Leftover notes may contain unsaved changes, so they're only reported.
+func (l *LocalService) Doctor(fix bool) (*models.DoctorReport, error) {
+	report := &models.DoctorReport{}
+
+	l.diagnoseSettings(report, fix)
+
+	if !pkg.IsDir(l.Config.NotesPath) {
+		return report, nil
+	}
+
+	return report, l.diagnoseDir(l.Config.NotesPath, report, fix)
+}
+
+// diagnoseSettings validates the settings file of local service.
+func (l *LocalService) diagnoseSettings(report *models.DoctorReport, fix bool) {
+	settingsPath := l.NotyaPath + models.SettingsName
+
+	settings, err := l.Settings(nil)
+	if err != nil {
+		report.Add(models.Diagnosis{
+			Kind:       models.InvalidSettingsDiagnosis,
+			Path:       settingsPath,
+			Message:    fmt.Sprintf("Settings couldn't be read | %v", err.Error()),
+			Suggestion: "Check permissions of settings file",
+		})
+		return
+	}
+
+	if !settings.IsValid() {
+		d := models.Diagnosis{
+			Kind:       models.InvalidSettingsDiagnosis,
+			Path:       settingsPath,
+			Message:    "Name, editor or notes path of settings is empty",
+			Suggestion: "Run doctor with --fix to complete them with defaults, or update them via settings command",
+		}
+
+		if fix {
+			defaults := models.InitSettings(l.NotyaPath)
+			if len(settings.Name) == 0 {
+				settings.Name = defaults.Name
+			}
+			if len(settings.Editor) == 0 {
+				settings.Editor = defaults.Editor
+			}
+			if len(settings.NotesPath) == 0 {
+				settings.NotesPath = defaults.NotesPath
+			}
+
+			if d.Fixed = l.WriteSettings(*settings) == nil; d.Fixed {
+				l.Config = *settings
+			}
+		}
+
+		report.Add(d)
+	}
+
+	if len(settings.NotesPath) > 0 && !pkg.IsDir(settings.NotesPath) {
+		d := models.Diagnosis{
+			Kind:       models.InvalidSettingsDiagnosis,
+			Path:       settings.NotesPath,
+			Message:    "Notes path doesn't exist, or isn't a folder",
+			Suggestion: "Run doctor with --fix to create it, or update notes path via settings command",
+		}
+
+		if fix && !pkg.FileExists(settings.NotesPath) {
+			d.Fixed = os.MkdirAll(settings.NotesPath, 0o750) == nil
+		}
+
+		report.Add(d)
+	}
+
+	if len(settings.Editor) > 0 {
+		if _, err := exec.LookPath(settings.Editor); err != nil {
+			report.Add(models.Diagnosis{
+				Kind:       models.InvalidSettingsDiagnosis,
+				Path:       settingsPath,
+				Message:    fmt.Sprintf("Editor %q isn't available on this machine", settings.Editor),
+				Suggestion: "Install the editor, or update it via settings command",
+			})
+		}
+	}
+}
+
+// diagnoseDir walks through the [dir] recursively, without following symlinks.
+func (l *LocalService) diagnoseDir(dir string, report *models.DoctorReport, fix bool) error {
+	if !strings.HasSuffix(dir, "/") {
+		dir += "/"
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		report.Add(models.Diagnosis{
+			Kind:       models.UnreadableDiagnosis,
+			Path:       dir,
+			Message:    fmt.Sprintf("Folder couldn't be read | %v", err.Error()),
+			Suggestion: "Check permissions of folder",
+		})
+		return nil
+	}
+
+	isRoot := pkg.NormalizePath(dir) == pkg.NormalizePath(l.Config.NotesPath)
+
+	for _, e := range entries {
+		path := dir + e.Name()
+
+		if pkg.IsIgnorable(e.Name(), models.NotyaIgnoreFiles) {
+			if !isRoot && (e.Name() == models.SettingsName || e.Name() == models.CheckpointsName) {
+				report.Add(models.Diagnosis{
+					Kind:       models.IgnoredDiagnosis,
+					Path:       path,
+					Message:    "File is hidden from nt, because its name is reserved",
+					Suggestion: "Rename the file, to make it visible",
+				})
+			}
+
+			continue
+		}
+
+		if pkg.IsTempTitle(e.Name()) {
+			report.Add(models.Diagnosis{
+				Kind:       models.LeftoverDiagnosis,
+				Path:       path,
+				Message:    "Temporary note is left after an interrupted remote editing",
+				Suggestion: "Review it for unsaved changes, then remove it",
+			})
+			continue
+		}
+
+		if e.Type()&os.ModeSymlink != 0 {
+			l.diagnoseLink(path, report, fix)
+			continue
+		}
+
+		if e.IsDir() {
+			if err := l.diagnoseDir(path, report, fix); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		file, err := os.Open(path)
+		if err == nil {
+			file.Close()
+			continue
+		}
+
+		d := models.Diagnosis{
+			Kind:       models.UnreadableDiagnosis,
+			Path:       path,
+			Message:    fmt.Sprintf("File couldn't be read | %v", err.Error()),
+			Suggestion: "Run doctor with --fix to make it readable, or check its permissions",
+		}
+
+		if info, infoErr := e.Info(); fix && infoErr == nil && errors.Is(err, os.ErrPermission) {
+			d.Fixed = os.Chmod(path, info.Mode().Perm()|0o600) == nil
+		}
+
+		report.Add(d)
+	}
+
+	return nil
+}
+
+// diagnoseLink checks whether the symlink at [path] is broken or makes a loop.
+func (l *LocalService) diagnoseLink(path string, report *models.DoctorReport, fix bool) {
+	d := models.Diagnosis{Path: path}
+
+	info, err := os.Stat(path)
+	switch {
+	case errors.Is(err, syscall.ELOOP):
+		d.Kind = models.LinkLoopDiagnosis
+		d.Message = "Symlink refers to itself"
+	case os.IsNotExist(err):
+		d.Kind = models.BrokenLinkDiagnosis
+		d.Message = "Target of symlink doesn't exist"
+	case err != nil:
+		d.Kind = models.UnreadableDiagnosis
+		d.Message = fmt.Sprintf("Target of symlink couldn't be read | %v", err.Error())
+	case info.IsDir():
+		target, err := filepath.EvalSymlinks(path)
+		parent, parentErr := filepath.EvalSymlinks(filepath.Dir(path))
+		if err != nil || parentErr != nil || !strings.HasPrefix(parent+"/", strings.TrimSuffix(target, "/")+"/") {
+			return
+		}
+
+		d.Kind = models.LinkLoopDiagnosis
+		d.Message = fmt.Sprintf("Symlink refers to its parent folder %v", target)
+	default:
+		return
+	}
+
+	if d.Kind == models.UnreadableDiagnosis {
+		d.Suggestion = "Check permissions of symlink's target"
+	} else {
+		d.Suggestion = "Run doctor with --fix to remove the symlink"
+		if fix {
+			d.Fixed = pkg.Delete(path) == nil
+		}
+	}
+
+	report.Add(d)
+}
@@ -11,6 +11,7 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/atotto/clipboard"
 	"github.com/insolite-dev/nt/assets"
@@ -219,8 +220,7 @@ func (l *LocalService) Remove(node models.Node) error {
 
 		// Remove all sub nodes of directory that're based at [nodePath].
 		for _, subNode := range subNodes {
-			title := node.ToFolder().Title + subNode.ToNote().Title
-			if err := l.Remove(models.Node{Title: title}); err != nil {
+			if err := l.Remove(models.Node{Title: subNode.ToNote().Title}); err != nil {
 				return err
 			}
 		}
@@ -325,7 +325,18 @@ func (l *LocalService) View(note models.Note) (*models.Note, error) {
 	}
 
 	// Re-generate note with full body.
-	modifiedNote := models.Note{Title: note.Title, Path: map[string]string{l.Type(): notePath}, Body: *res}
+	modifiedNote := models.Note{Title: note.Title, Path: map[string]string{l.Type(): notePath}, Body: *res, Hash: pkg.Hash(*res)}
+
+	// Fill metadata of note from file stat info.
+	if info, err := os.Stat(notePath); err == nil {
+		modifiedNote.UpdatedAt = info.ModTime()
+		modifiedNote.CreatedAt = pkg.CreationTime(notePath, info)
+		modifiedNote.Size = info.Size()
+		modifiedNote.UpdatedBy = pkg.FileOwner(info)
+	}
+
+	// Invalid front matter is kept as a part of body, without structured metadata.
+	modifiedNote.Meta, _ = models.ParseFrontMatter(modifiedNote.Body)
 
 	return &modifiedNote, nil
 }
@@ -409,11 +420,13 @@ func (l *LocalService) Mkdir(dir models.Folder) (*models.Folder, error) {
 }
 
 // GetAll fetches all nodes(files and folders) from current active local directory.
+// Nodes of [additional] sub folder are titled by their full paths, like other nodes.
 func (l *LocalService) GetAll(additional, typ string, ignore []string) ([]models.Node, []string, error) {
+	root, _ := l.GeneratePath(l.Config.NotesPath, models.Node{})
 	path, _ := l.GeneratePath(l.Config.NotesPath, models.Node{Title: additional})
 
 	// Generate array of all file names that are located in [path].
-	files, pretty, err := pkg.ListDir(path, path, typ, ignore, 0)
+	files, pretty, err := pkg.ListDir(root, path, typ, ignorePatterns(l.Config, ignore), 0)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -436,16 +449,126 @@ func (l *LocalService) GetAll(additional, typ string, ignore []string) ([]models
 		if !pkg.IsDir(p) {
 			data, err := l.View(node.ToNote())
 			if err == nil {
-				node = models.Node{Type: models.FILE, Title: title, Path: path, Body: data.Body, Pretty: pretty[i]}
+				node = data.ToNode()
+				node.Title, node.Path, node.Pretty = title, path, pretty[i]
 			}
 		}
 
+		// Fill metadata of node from file stat info.
+		if info, err := os.Stat(p); err == nil {
+			node.UpdatedAt = info.ModTime()
+			node.CreatedAt = pkg.CreationTime(p, info)
+			node.UpdatedBy = pkg.FileOwner(info)
+		}
+
 		nodes = append(nodes, node)
 	}
 
 	return nodes, files, nil
 }
 
+// GetChanged fetches all nodes that were modified after [since].
+// Modification time of nodes is taken from file system.
+func (l *LocalService) GetChanged(since time.Time, ignore []string) ([]models.Node, time.Time, error) {
+	mark := time.Now()
+
+	nodes, _, err := l.GetAll("", "", ignore)
+	if err != nil || since.IsZero() {
+		return nodes, mark, err
+	}
+
+	changed := []models.Node{}
+	for _, node := range nodes {
+		if node.UpdatedAt.After(since) {
+			changed = append(changed, node)
+		}
+	}
+
+	return changed, mark, nil
+}
+
+// GetHashes generates content hashes of all nodes from local file system.
+func (l *LocalService) GetHashes(ignore []string) (map[string]string, error) {
+	nodes, err := l.GetNodes(ignore)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := map[string]string{}
+	for key, node := range nodes {
+		hashes[key] = node.Hash
+	}
+
+	return hashes, nil
+}
+
+// GetNodes collects all nodes from local file system, without their bodies.
+func (l *LocalService) GetNodes(ignore []string) (map[string]models.Node, error) {
+	all, _, err := l.GetAll("", "", ignore)
+	if err != nil && err != assets.EmptyWorkingDirectory {
+		return nil, err
+	}
+
+	nodes := map[string]models.Node{}
+	for _, node := range all {
+		node.Body = ""
+		nodes[HashKey(node.Title)] = node
+	}
+
+	return nodes, nil
+}
+
+// IsIgnored checks if the node of [title] is ignored at local service, by the
+// ignore patterns of settings and the ignore files of its parent folders.
+func (l *LocalService) IsIgnored(title string, isDir bool) bool {
+	rules := pkg.LoadIgnore(l.Config.NotesPath, title, ignorePatterns(l.Config, models.NotyaIgnoreFiles))
+	return rules.Match(title, isDir)
+}
+
+// withoutIgnored filters out the [nodes] that're ignored at local service.
+func (l *LocalService) withoutIgnored(nodes []models.Node) []models.Node {
+	res := []models.Node{}
+	for _, n := range nodes {
+		if !l.IsIgnored(n.Title, n.IsFolder()) {
+			res = append(res, n)
+		}
+	}
+
+	return res
+}
+
+// Checkpoint returns the last successful fetch checkpoint of given [remote].
+func (l *LocalService) Checkpoint(remote ServiceRepo) models.Checkpoint {
+	_, collection := remote.Path()
+
+	data, err := pkg.ReadBody(l.NotyaPath + models.CheckpointsName)
+	if err != nil {
+		return models.Checkpoint{Service: remote.Type(), Collection: collection}
+	}
+
+	return models.DecodeCheckpoints(*data).Get(remote.Type(), collection)
+}
+
+// WriteCheckpoint saves [checkpoint] as last successful fetch checkpoint of its service.
+// Providing a zero-timed checkpoint without hashes resets it, which forces a full scan
+// on next fetch, and makes differing nodes be overwritten by the direction of next sync.
+func (l *LocalService) WriteCheckpoint(checkpoint models.Checkpoint) error {
+	path := l.NotyaPath + models.CheckpointsName
+
+	checkpoints := models.Checkpoints{}
+	if data, err := pkg.ReadBody(path); err == nil {
+		checkpoints = models.DecodeCheckpoints(*data)
+	}
+
+	if checkpoint.Time.IsZero() && len(checkpoint.Hashes) == 0 {
+		delete(checkpoints, checkpoint.Service)
+	} else {
+		checkpoints[checkpoint.Service] = checkpoint
+	}
+
+	return pkg.WriteNote(path, checkpoints.ToString())
+}
+
 // MoveNotes moves all notes from "CURRENT" path to new path(given by settings parameter).
 func (l *LocalService) MoveNotes(settings models.Settings) error {
 	nodes, _, err := l.GetAll("", "", models.NotyaIgnoreFiles)
@@ -497,33 +620,56 @@ func (l *LocalService) MoveNotes(settings models.Settings) error {
 }
 
 // Fetch creates a clone of nodes(that doesn't exists on [l](local-service)) from given [remote] service.
-func (l *LocalService) Fetch(remote ServiceRepo) ([]models.Node, []error) {
-	nodes, _, err := remote.GetAll("", "", models.NotyaIgnoreFiles)
+//
+// Only nodes that were changed after the last successful fetch are requested from [remote].
+// If there is no checkpoint for [remote], all nodes would be fetched.
+//
+// Existing notes are updated only if they weren't changed locally since their last sync.
+// Otherwise, remote version is saved as a conflicted copy next to the local note, and
+// the conflict is reported. Notes without a recorded sync are overwritten by remote.
+// See [models.Checkpoint.DirectedSide].
+func (l *LocalService) Fetch(remote ServiceRepo, patterns []string) ([]models.Node, []error) {
+	checkpoint := l.Checkpoint(remote)
+
+	var nodes []models.Node
+	var mark time.Time
+	var err error
+
+	// Selective fetch without checkpoint lists only the common folder of patterns.
+	if checkpoint.Time.IsZero() && len(PatternsPrefix(patterns)) > 0 {
+		nodes, err = ListSelected(remote, patterns, models.NotyaIgnoreFiles)
+	} else {
+		nodes, mark, err = remote.GetChanged(checkpoint.Time, models.NotyaIgnoreFiles)
+		nodes = SelectNodes(nodes, patterns)
+	}
+
 	if err != nil {
 		return nil, []error{err}
 	}
 
+	nodes = l.withoutIgnored(nodes)
+
 	// Sort nodes via title-len ascending order.
 	sort.Slice(
 		nodes,
 		func(i, j int) bool { return len(nodes[i].Title) < len(nodes[j].Title) },
 	)
 
+	hashes, err := l.GetHashes(models.NotyaIgnoreFiles)
+	if err != nil {
+		return nil, []error{err}
+	}
+
 	fetched := []models.Node{}
 	errors := []error{}
 
 	for _, node := range nodes {
-		exists, _ := l.IsNodeExists(node)
-		if exists && !node.IsFolder() {
-			local, err := l.View(node.ToNote())
-			if err != nil {
-				errors = append(errors, assets.CannotDoSth("fetch", node.Title, err))
-				continue
-			}
+		key := HashKey(node.Title)
+		localHash, exists := hashes[key]
 
-			if local.Body != node.Body {
-				local.Body = node.Body
-				if _, err := l.Edit(*local); err != nil {
+		if node.IsFolder() {
+			if !exists {
+				if _, err := l.Mkdir(node.ToFolder()); err != nil {
 					errors = append(errors, assets.CannotDoSth("fetch", node.Title, err))
 					continue
 				}
@@ -531,36 +677,78 @@ func (l *LocalService) Fetch(remote ServiceRepo) ([]models.Node, []error) {
 				fetched = append(fetched, node)
 			}
 
+			checkpoint.Record(key, "")
 			continue
 		}
 
-		if node.IsFolder() && !exists {
-			if _, err := l.Mkdir(node.ToFolder()); err != nil {
+		remoteHash := node.Hash
+		if len(remoteHash) == 0 {
+			remoteHash = pkg.Hash(node.Body)
+		}
+
+		if !exists {
+			if _, err := l.Create(node.ToNote()); err != nil {
 				errors = append(errors, assets.CannotDoSth("fetch", node.Title, err))
-			} else {
-				fetched = append(fetched, node)
+				continue
 			}
 
+			fetched = append(fetched, node)
+			checkpoint.Record(key, remoteHash)
 			continue
 		}
 
-		if exists {
-			continue
-		}
+		switch checkpoint.DirectedSide(key, localHash, remoteHash, models.RemoteSide) {
+		case models.RemoteSide:
+			if _, err := l.Edit(models.Note{Title: node.Title, Body: node.Body}); err != nil {
+				errors = append(errors, assets.CannotDoSth("fetch", node.Title, err))
+				continue
+			}
 
-		if _, err := l.Create(node.ToNote()); err != nil {
-			errors = append(errors, assets.CannotDoSth("fetch", node.Title, err))
-		} else {
 			fetched = append(fetched, node)
+
+		case models.BothSides:
+			copyTime := node.UpdatedAt
+			if copyTime.IsZero() {
+				copyTime = time.Now()
+			}
+
+			conflicted := models.Note{Title: ConflictTitle(node.Title, copyTime), Body: node.Body}
+			if _, err := l.Create(conflicted); err != nil {
+				errors = append(errors, assets.CannotDoSth("fetch", node.Title, err))
+				continue
+			}
+
+			errors = append(errors, assets.SyncConflict(node.Title, conflicted.Title))
+
+		case models.LocalSide:
+			// Remote is unchanged, local changes are pushed by the next push.
+			continue
 		}
+
+		checkpoint.Record(key, remoteHash)
+	}
+
+	// Move the high-water mark, only if everything was fetched successfully.
+	// Otherwise, failed (or unselected) nodes would be skipped by the next fetch.
+	if len(errors) == 0 && len(patterns) == 0 {
+		checkpoint.Time = mark
+	}
+
+	if err := l.WriteCheckpoint(checkpoint); err != nil {
+		errors = append(errors, err)
 	}
 
 	return fetched, errors
 }
 
 // Push uploads nodes(that doesn't exists on given remote) from [l](current) to given [remote].
-func (l *LocalService) Push(remote ServiceRepo) ([]models.Node, []error) {
-	nodes, _, err := l.GetAll("", "", models.NotyaIgnoreFiles)
+//
+// Existing notes are updated only if they weren't changed remotely since their last sync.
+// Otherwise, remote note is left untouched and the conflict is reported, so the next
+// fetch would save the remote version next to the local note. Notes without a recorded
+// sync are overwritten by local. See [models.Checkpoint.DirectedSide].
+func (l *LocalService) Push(remote ServiceRepo, patterns []string) ([]models.Node, []error) {
+	nodes, err := ListSelected(l, patterns, models.NotyaIgnoreFiles)
 	if err != nil {
 		return nil, []error{err}
 	}
@@ -570,55 +758,92 @@ func (l *LocalService) Push(remote ServiceRepo) ([]models.Node, []error) {
 		func(i, j int) bool { return len(nodes[i].Title) < len(nodes[j].Title) },
 	)
 
+	hashes, err := remote.GetHashes(models.NotyaIgnoreFiles)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	checkpoint := l.Checkpoint(remote)
+
 	pushed := []models.Node{}
 	errors := []error{}
 
 	for _, node := range nodes {
-		path, err := l.GeneratePath(l.Config.NotesPath, node)
-		if err != nil {
-			continue
-		}
+		key := HashKey(node.Title)
+		remoteHash, exists := hashes[key]
 
-		exists, _ := remote.IsNodeExists(node)
+		if node.IsFolder() {
+			if !exists {
+				if _, err := remote.Mkdir(node.ToFolder()); err != nil {
+					errors = append(errors, assets.CannotDoSth("push", node.Title, err))
+					continue
+				}
 
-		if pkg.IsDir(path) && !exists {
-			if _, err := remote.Mkdir(node.ToFolder()); err != nil {
-				errors = append(errors, assets.CannotDoSth("push", node.Title, err))
-			} else {
 				pushed = append(pushed, node)
 			}
 
+			checkpoint.Record(key, "")
 			continue
 		}
 
-		r, _ := remote.View(node.ToNote())
 		if !exists {
 			if _, err := remote.Create(node.ToNote()); err != nil {
 				errors = append(errors, assets.CannotDoSth("push", node.Title, err))
-			} else {
-				pushed = append(pushed, node)
+				continue
 			}
 
+			pushed = append(pushed, node)
+			checkpoint.Record(key, node.Hash)
 			continue
 		}
 
-		if r.Body != node.Body {
+		// Nodes that were created before hashing was introduced, haven't got hash.
+		// So, their bodies must be compared directly.
+		if len(remoteHash) == 0 {
+			if r, err := remote.View(node.ToNote()); err == nil {
+				remoteHash = pkg.Hash(r.Body)
+			}
+		}
+
+		switch checkpoint.DirectedSide(key, node.Hash, remoteHash, models.LocalSide) {
+		case models.LocalSide:
 			if _, err := remote.Edit(node.ToNote()); err != nil {
 				errors = append(errors, assets.CannotDoSth("push", node.Title, err))
-			} else {
-				pushed = append(pushed, node)
+				continue
 			}
+
+			pushed = append(pushed, node)
+
+		case models.BothSides:
+			errors = append(errors, assets.SyncConflict(node.Title, ""))
+			continue
+
+		case models.RemoteSide:
+			// Local is unchanged, remote changes are fetched by the next fetch.
+			continue
 		}
+
+		checkpoint.Record(key, node.Hash)
+	}
+
+	if err := l.WriteCheckpoint(checkpoint); err != nil {
+		errors = append(errors, err)
 	}
 
 	return pushed, errors
 }
 
 // Migrate overwrites all notes of given [remote] service with [l](current-service).
-func (l *LocalService) Migrate(remote ServiceRepo) ([]models.Node, []error) {
-	if _, err := remote.ClearNodes(); err != nil {
+func (l *LocalService) Migrate(remote ServiceRepo, patterns []string) ([]models.Node, []error) {
+	if _, err := ClearSelected(remote, patterns); err != nil {
 		return nil, err
 	}
 
-	return l.Push(remote)
+	// Collection of remote is re-created, so the next fetch must be a full scan.
+	if len(patterns) == 0 {
+		_, collection := remote.Path()
+		_ = l.WriteCheckpoint(models.Checkpoint{Service: remote.Type(), Collection: collection})
+	}
+
+	return l.Push(remote, patterns)
 }
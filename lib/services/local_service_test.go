@@ -0,0 +1,105 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/lib/services"
+)
+
+// remoteService is a local service at another directory, used as a remote.
+// Like remote services, it doesn't use the local paths of provided nodes.
+type remoteService struct {
+	*services.LocalService
+}
+
+func (r remoteService) Create(note models.Note) (*models.Note, error) {
+	note.Path = nil
+	return r.LocalService.Create(note)
+}
+
+func (r remoteService) Edit(note models.Note) (*models.Note, error) {
+	note.Path = nil
+	return r.LocalService.Edit(note)
+}
+
+func (r remoteService) View(note models.Note) (*models.Note, error) {
+	note.Path = nil
+	return r.LocalService.View(note)
+}
+
+func (r remoteService) GetChanged(since time.Time, ignore []string) ([]models.Node, time.Time, error) {
+	nodes, mark, err := r.LocalService.GetChanged(since, ignore)
+	for i := range nodes {
+		nodes[i].Path = nil
+	}
+
+	return nodes, mark, err
+}
+
+// newTestService creates a local service, with the given notes.
+func newTestService(t *testing.T, notes map[string]string) *services.LocalService {
+	dir := t.TempDir() + "/"
+	for title, body := range notes {
+		if err := os.WriteFile(filepath.Join(dir, title), []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return &services.LocalService{NotyaPath: dir, Config: models.Settings{NotesPath: dir}}
+}
+
+func TestLocalServiceSyncWithoutRecordedHashes(t *testing.T) {
+	tests := []struct {
+		testName string
+		sync     func(l *services.LocalService, remote services.ServiceRepo, patterns []string) ([]models.Node, []error)
+		expected string
+	}{
+		{
+			testName: "push should overwrite remote note",
+			sync:     (*services.LocalService).Push,
+			expected: "local edit",
+		},
+		{
+			testName: "fetch should overwrite local note",
+			sync:     (*services.LocalService).Fetch,
+			expected: "remote edit",
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			local := newTestService(t, map[string]string{"todo.md": "local edit"})
+			remote := remoteService{newTestService(t, map[string]string{"todo.md": "remote edit"})}
+
+			// Nodes synced before hashes were recorded, have no base.
+			if _, errs := td.sync(local, remote, nil); len(errs) > 0 {
+				t.Fatalf("Sync of unrecorded note must not conflict: Got: %v", errs)
+			}
+
+			for _, service := range []services.ServiceRepo{local, remote} {
+				got, err := service.View(models.Note{Title: "todo.md"})
+				if err != nil || got.Body != td.expected {
+					t.Errorf("Synced note sum is different, Want: %v | Got: %v, %v", td.expected, got, err)
+				}
+			}
+
+			// Now the base is recorded, so changes at both sides must conflict.
+			os.WriteFile(filepath.Join(local.Config.NotesPath, "todo.md"), []byte("local again"), 0o600)
+			remote.Edit(models.Note{Title: "todo.md", Body: "remote again"})
+
+			if _, errs := local.Push(remote, nil); len(errs) != 1 {
+				t.Errorf("Push of note changed at both sides must conflict: Got: %v", errs)
+			}
+		})
+	}
+}
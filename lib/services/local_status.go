@@ -0,0 +1,85 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services
+
+import (
+	"sort"
+
+	"github.com/insolite-dev/nt/assets"
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/pkg"
+)
+
+// Status compares nodes of local service with nodes of [remote] service,
+// by their content hashes, so bodies of remote notes aren't transferred.
+//
+// Side of modified notes is decided by the hashes recorded at their last sync with
+// remote, see [models.Checkpoint.ChangedSide]. It's empty for never synced notes.
+func (l *LocalService) Status(remote ServiceRepo) (*models.StatusReport, error) {
+	nodes, _, err := l.GetAll("", "", models.NotyaIgnoreFiles)
+	if err != nil && err != assets.EmptyWorkingDirectory {
+		return nil, err
+	}
+
+	remoteNodes, err := remote.GetNodes(models.NotyaIgnoreFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint := l.Checkpoint(remote)
+
+	report := models.StatusReport{Remote: remote.Type()}
+	seen := map[string]bool{}
+
+	for _, node := range nodes {
+		key := HashKey(node.Title)
+		seen[key] = true
+
+		status := models.NodeStatus{Title: node.Title, Type: node.Type, State: models.IdenticalState}
+
+		remoteNode, exists := remoteNodes[key]
+		switch {
+		case !exists:
+			status.State = models.LocalOnlyState
+		case node.IsFile():
+			// Nodes that were created before hashing was introduced, haven't got hash.
+			// So, their bodies must be compared directly.
+			remoteHash := remoteNode.Hash
+			if len(remoteHash) == 0 {
+				if r, err := remote.View(node.ToNote()); err == nil {
+					remoteHash = pkg.Hash(r.Body)
+				}
+			}
+
+			if remoteHash != node.Hash {
+				status.State = models.ModifiedState
+				if _, synced := checkpoint.Hashes[key]; synced {
+					status.Side = checkpoint.ChangedSide(key, node.Hash, remoteHash)
+				}
+			}
+		}
+
+		report.Nodes = append(report.Nodes, status)
+	}
+
+	for key, node := range remoteNodes {
+		if seen[key] || l.IsIgnored(key, node.IsFolder()) {
+			continue
+		}
+
+		status := models.NodeStatus{Title: key, Type: models.FILE, State: models.RemoteOnlyState}
+		if node.IsFolder() {
+			status = models.NodeStatus{Title: key + "/", Type: models.FOLDER, State: models.RemoteOnlyState}
+		}
+
+		report.Nodes = append(report.Nodes, status)
+	}
+
+	sort.Slice(report.Nodes, func(i, j int) bool { return report.Nodes[i].Title < report.Nodes[j].Title })
+
+	return &report, nil
+}
@@ -0,0 +1,547 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/insolite-dev/nt/assets"
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/pkg"
+)
+
+// Query is a parsed filter expression of nodes.
+//
+// Terms are written as [field][operator][value] and could be combined by
+// AND, OR, NOT and parentheses. Terms without an operator are implicitly
+// joined by AND.
+//
+//	Example:
+//
+//	tag:oncall AND modified>2026-09-01 AND path:runbooks/ AND NOT title:draft
+//
+// Fields:
+//   - tag:x           note has tag x, or one of its nested tags.
+//   - path:x          node is at x, or under x folder (glob patterns are allowed).
+//   - title:x         name of node contains x (glob patterns are allowed).
+//   - body:x          content of note contains x.
+//   - type:x          node is a "file" or a "folder".
+//   - author:x        node was last modified by x.
+//   - modified, created  compared with a date(2006-01-02), a time(RFC3339),
+//     or a period ago(like: 7d, 2w, 12h).
+//   - size            compared with a size(like: 512, 10KB, 1MB).
+//   - @name           saved query of settings.
+//
+// A bare word(without field) matches the title or the content of note.
+// Operators: ":", "=", "!=", ">", ">=", "<", "<=".
+type Query struct {
+	// Source is the raw text of query.
+	Source string
+
+	root queryExpr
+}
+
+// queryExpr is a node of parsed query expression.
+type queryExpr interface {
+	match(node models.Node, now time.Time) bool
+}
+
+// queryAnd matches the nodes that are matched by all of its expressions.
+type queryAnd []queryExpr
+
+func (q queryAnd) match(node models.Node, now time.Time) bool {
+	for _, e := range q {
+		if !e.match(node, now) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// queryOr matches the nodes that are matched by one of its expressions.
+type queryOr []queryExpr
+
+func (q queryOr) match(node models.Node, now time.Time) bool {
+	for _, e := range q {
+		if e.match(node, now) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// queryNot matches the nodes that aren't matched by its expression.
+type queryNot struct{ expr queryExpr }
+
+func (q queryNot) match(node models.Node, now time.Time) bool {
+	return !q.expr.match(node, now)
+}
+
+// queryTerm is a single [field][operator][value] filter.
+type queryTerm struct {
+	field, op, value string
+}
+
+// Available operators of query terms, longer ones come first.
+var queryOperators = []string{">=", "<=", "!=", ":", "=", ">", "<"}
+
+// ParseQuery parses given query [source]. Saved queries(referred as @name)
+// are looked up from [saved] queries, usually taken from settings.
+func ParseQuery(source string, saved map[string]string) (*Query, error) {
+	p := &queryParser{source: source, saved: saved, seen: map[string]bool{}}
+
+	root, err := p.parse(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Query{Source: source, root: root}, nil
+}
+
+// Match checks if given node is matched by query.
+func (q *Query) Match(node models.Node) bool {
+	return q.root.match(node, time.Now())
+}
+
+// Select filters the nodes, that are matched by query.
+func (q *Query) Select(nodes []models.Node) []models.Node {
+	now := time.Now()
+
+	selected := []models.Node{}
+	for _, node := range nodes {
+		if q.root.match(node, now) {
+			selected = append(selected, node)
+		}
+	}
+
+	return selected
+}
+
+// queryParser is a recursive descent parser of query expressions.
+type queryParser struct {
+	source string
+	saved  map[string]string
+
+	// seen is the saved queries, that are being parsed, to detect recursive references.
+	seen map[string]bool
+
+	tokens []string
+	pos    int
+}
+
+// parse parses a whole query(or saved query) text.
+func (p *queryParser) parse(text string) (queryExpr, error) {
+	tokens, err := tokenizeQuery(text)
+	if err != nil {
+		return nil, assets.InvalidQuery(p.source, err.Error())
+	}
+
+	if len(tokens) == 0 {
+		return nil, assets.InvalidQuery(p.source, "query is empty")
+	}
+
+	sub := &queryParser{source: p.source, saved: p.saved, seen: p.seen, tokens: tokens}
+
+	expr, err := sub.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if sub.pos < len(sub.tokens) {
+		return nil, assets.InvalidQuery(p.source, fmt.Sprintf("unexpected %q", sub.tokens[sub.pos]))
+	}
+
+	return expr, nil
+}
+
+// peek returns the current token, or an empty string at the end of tokens.
+func (p *queryParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+
+	return ""
+}
+
+func (p *queryParser) parseOr() (queryExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	or := queryOr{left}
+	for p.peek() == "OR" {
+		p.pos++
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		or = append(or, right)
+	}
+
+	if len(or) == 1 {
+		return left, nil
+	}
+
+	return or, nil
+}
+
+func (p *queryParser) parseAnd() (queryExpr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	and := queryAnd{left}
+	for {
+		next := p.peek()
+		if next == "AND" {
+			p.pos++
+		} else if len(next) == 0 || next == "OR" || next == ")" {
+			break
+		}
+
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		and = append(and, right)
+	}
+
+	if len(and) == 1 {
+		return left, nil
+	}
+
+	return and, nil
+}
+
+func (p *queryParser) parseNot() (queryExpr, error) {
+	if p.peek() == "NOT" {
+		p.pos++
+
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		return queryNot{expr}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (queryExpr, error) {
+	token := p.peek()
+
+	switch token {
+	case "":
+		return nil, assets.InvalidQuery(p.source, "unexpected end of query")
+	case "AND", "OR", ")":
+		return nil, assets.InvalidQuery(p.source, fmt.Sprintf("unexpected %q", token))
+	case "(":
+		p.pos++
+
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.peek() != ")" {
+			return nil, assets.InvalidQuery(p.source, "missing closing parenthesis")
+		}
+
+		p.pos++
+		return expr, nil
+	}
+
+	p.pos++
+
+	if strings.HasPrefix(token, "@") {
+		return p.parseSaved(token[1:])
+	}
+
+	return parseTerm(p.source, token)
+}
+
+// parseSaved parses the saved query of given name.
+func (p *queryParser) parseSaved(name string) (queryExpr, error) {
+	text, exists := p.saved[name]
+	if !exists {
+		return nil, assets.InvalidQuery(p.source, fmt.Sprintf("saved query @%v doesn't exist", name))
+	}
+
+	if p.seen[name] {
+		return nil, assets.InvalidQuery(p.source, fmt.Sprintf("saved query @%v refers to itself", name))
+	}
+
+	p.seen[name] = true
+	defer delete(p.seen, name)
+
+	return p.parse(text)
+}
+
+// parseTerm parses a single [field][operator][value] token.
+func parseTerm(source, token string) (queryExpr, error) {
+	term := queryTerm{value: token}
+
+	if i := strings.IndexAny(token, ":=!<>"); i > 0 && isQueryField(token[:i]) {
+		term.field = strings.ToLower(token[:i])
+		for _, op := range queryOperators {
+			if strings.HasPrefix(token[i:], op) {
+				term.op, term.value = op, token[i+len(op):]
+				break
+			}
+		}
+
+		if len(term.op) == 0 {
+			return nil, assets.InvalidQuery(source, fmt.Sprintf("unknown operator of %q", token))
+		}
+	}
+
+	if len(term.value) == 0 {
+		return nil, assets.InvalidQuery(source, fmt.Sprintf("missing value of %q", token))
+	}
+
+	switch term.field {
+	case "modified", "created":
+		if !isQueryTime(term.value) {
+			return nil, assets.InvalidQuery(source, fmt.Sprintf("%q isn't a valid date, time or period", term.value))
+		}
+	case "size":
+		if _, err := parseQuerySize(term.value); err != nil {
+			return nil, assets.InvalidQuery(source, err.Error())
+		}
+	case "type":
+		if t := models.NodeType(strings.ToUpper(term.value)); t != models.FILE && t != models.FOLDER {
+			return nil, assets.InvalidQuery(source, `type must be "file" or "folder"`)
+		}
+	}
+
+	if ordered := term.op == ">" || term.op == ">=" || term.op == "<" || term.op == "<="; ordered &&
+		term.field != "modified" && term.field != "created" && term.field != "size" {
+		return nil, assets.InvalidQuery(source, fmt.Sprintf("%v cannot be compared by %v", term.field, term.op))
+	}
+
+	return term, nil
+}
+
+// isQueryField checks if [field] is a known field of query terms.
+func isQueryField(field string) bool {
+	switch strings.ToLower(field) {
+	case "tag", "path", "title", "body", "type", "author", "modified", "created", "size":
+		return true
+	}
+
+	return false
+}
+
+func (t queryTerm) match(node models.Node, now time.Time) bool {
+	matched := t.matchValue(node, now)
+	if t.op == "!=" {
+		return !matched
+	}
+
+	return matched
+}
+
+// matchValue checks the value of term on the node, operator of ordered fields is applied here.
+func (t queryTerm) matchValue(node models.Node, now time.Time) bool {
+	value := strings.ToLower(t.value)
+
+	switch t.field {
+	case "tag":
+		return models.HasTag(node.Tags(), t.value)
+	case "path":
+		return pkg.MatchPath(node.Title, []string{t.value})
+	case "title":
+		return matchText(path.Base(HashKey(node.Title)), t.value)
+	case "body":
+		return node.IsFile() && strings.Contains(strings.ToLower(node.Body), value)
+	case "type":
+		return node.Type == models.NodeType(strings.ToUpper(t.value))
+	case "author":
+		return strings.Contains(strings.ToLower(node.UpdatedBy), value)
+	case "modified", "created":
+		at := node.UpdatedAt
+		if t.field == "created" {
+			at = node.CreatedAt
+		}
+
+		return !at.IsZero() && compareTime(at, t.op, t.value, now)
+	case "size":
+		size, _ := parseQuerySize(t.value)
+		return node.IsFile() && compareInt(node.Size, t.op, size)
+	}
+
+	return strings.Contains(strings.ToLower(node.Title), value) ||
+		(node.IsFile() && strings.Contains(strings.ToLower(node.Body), value))
+}
+
+// matchText checks if [text] contains [value], or matches it as a glob pattern.
+func matchText(text, value string) bool {
+	if strings.ContainsAny(value, "*?[") {
+		matched, _ := path.Match(value, text)
+		return matched
+	}
+
+	return strings.Contains(strings.ToLower(text), strings.ToLower(value))
+}
+
+// compareTime compares [at] with the time range of [value] by [op], see [parseQueryTime].
+func compareTime(at time.Time, op, value string, now time.Time) bool {
+	from, to, period := parseQueryTime(value, now)
+
+	switch op {
+	case ">":
+		return !at.Before(to)
+	case ">=":
+		return !at.Before(from)
+	case "<":
+		return at.Before(from)
+	case "<=":
+		return at.Before(to)
+	}
+
+	// Equality of a period means being in that period, till now.
+	if period {
+		return !at.Before(from)
+	}
+
+	return !at.Before(from) && at.Before(to)
+}
+
+// compareInt compares [a] with [b] by [op].
+func compareInt(a int64, op string, b int64) bool {
+	switch op {
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	}
+
+	return a == b
+}
+
+// parseQueryTime parses the time value of query as a [from, to) range. Value could be:
+//   - a date(2006-01-02), which is the range of whole day.
+//   - a time(2006-01-02T15:04 or RFC3339), which is the range of that instant.
+//   - a period ago from [now](like: 7d, 2w, 12h), which is the instant of period start.
+func parseQueryTime(value string, now time.Time) (from time.Time, to time.Time, period bool) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, t.AddDate(0, 0, 1), false
+	}
+
+	for _, layout := range []string{"2006-01-02T15:04", time.RFC3339} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, t.Add(time.Nanosecond), false
+		}
+	}
+
+	if len(value) < 2 {
+		return time.Time{}, time.Time{}, false
+	}
+
+	var start time.Time
+	if n, err := strconv.Atoi(value[:len(value)-1]); err == nil && n >= 0 && strings.HasSuffix(value, "d") {
+		start = now.AddDate(0, 0, -n)
+	} else if err == nil && n >= 0 && strings.HasSuffix(value, "w") {
+		start = now.AddDate(0, 0, -7*n)
+	} else if d, err := time.ParseDuration(value); err == nil {
+		start = now.Add(-d)
+	} else {
+		return time.Time{}, time.Time{}, false
+	}
+
+	return start, start.Add(time.Nanosecond), true
+}
+
+// isQueryTime checks if [value] could be parsed by [parseQueryTime].
+func isQueryTime(value string) bool {
+	from, _, _ := parseQueryTime(value, time.Now())
+	return !from.IsZero()
+}
+
+// parseQuerySize parses the size value of query, like: 512, 10KB, 1MB.
+func parseQuerySize(value string) (int64, error) {
+	units := []struct {
+		suffix string
+		size   int64
+	}{
+		{"KB", 1 << 10}, {"MB", 1 << 20}, {"GB", 1 << 30}, {"K", 1 << 10}, {"M", 1 << 20}, {"G", 1 << 30}, {"B", 1},
+	}
+
+	number, multiplier := strings.ToUpper(value), int64(1)
+	for _, u := range units {
+		if strings.HasSuffix(number, u.suffix) {
+			number, multiplier = strings.TrimSuffix(number, u.suffix), u.size
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(number, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%q isn't a valid size", value)
+	}
+
+	return int64(n * float64(multiplier)), nil
+}
+
+// tokenizeQuery splits query into tokens. Parentheses are separate tokens,
+// and double quoted parts of token are kept together without quotes.
+func tokenizeQuery(text string) ([]string, error) {
+	tokens := []string{}
+
+	var current strings.Builder
+	inToken, quoted := false, false
+
+	flush := func() {
+		if inToken {
+			tokens = append(tokens, current.String())
+		}
+
+		current.Reset()
+		inToken = false
+	}
+
+	for _, r := range text {
+		switch {
+		case r == '"':
+			quoted, inToken = !quoted, true
+		case quoted:
+			current.WriteRune(r)
+		case unicode.IsSpace(r):
+			flush()
+		case r == '(' || r == ')':
+			flush()
+			tokens = append(tokens, string(r))
+		default:
+			current.WriteRune(r)
+			inToken = true
+		}
+	}
+
+	if quoted {
+		return nil, fmt.Errorf("missing closing quote")
+	}
+
+	flush()
+	return tokens, nil
+}
@@ -0,0 +1,111 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/lib/services"
+)
+
+func TestQuerySelect(t *testing.T) {
+	day := func(d string) time.Time {
+		t, _ := time.ParseInLocation("2006-01-02 15:04", d, time.Local)
+		return t
+	}
+
+	nodes := []models.Node{
+		{Type: models.FOLDER, Title: "runbooks/", UpdatedAt: day("2026-08-01 10:00")},
+		{
+			Type: models.FILE, Title: "runbooks/db.md", Body: "#oncall restart the database",
+			UpdatedAt: day("2026-09-02 09:00"), CreatedAt: day("2026-01-01 09:00"), Size: 2048, UpdatedBy: "anna@laptop",
+		},
+		{
+			Type: models.FILE, Title: "runbooks/db-draft.md", Body: "---\ntags: [oncall]\n---\nwip",
+			UpdatedAt: day("2026-09-01 18:00"), Size: 100, UpdatedBy: "bob@desktop",
+		},
+		{
+			Type: models.FILE, Title: "ideas.md", Body: "restart with #ideas",
+			UpdatedAt: time.Now().Add(-time.Hour), Size: 10,
+		},
+	}
+
+	saved := map[string]string{
+		"oncall": "tag:oncall AND path:runbooks/",
+		"recent": "modified>2d",
+	}
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{query: "tag:oncall AND modified>2026-09-01 AND path:runbooks/ AND NOT title:draft", expected: []string{"runbooks/db.md"}},
+		{query: "tag:oncall modified:2026-09-01", expected: []string{"runbooks/db-draft.md"}},
+		{query: "modified>=2026-09-01 AND modified<=2026-09-01", expected: []string{"runbooks/db-draft.md"}},
+		{query: "modified<2026-09-01", expected: []string{"runbooks/"}},
+		{query: "type:folder OR size>1KB", expected: []string{"runbooks/", "runbooks/db.md"}},
+		{query: "restart", expected: []string{"runbooks/db.md", "ideas.md"}},
+		{query: "body:\"the database\"", expected: []string{"runbooks/db.md"}},
+		{query: "title:db*.md AND author!=anna", expected: []string{"runbooks/db-draft.md"}},
+		{query: "created<2026-06-01", expected: []string{"runbooks/db.md"}},
+		{query: "@oncall AND NOT (title:draft OR @recent)", expected: []string{"runbooks/db.md"}},
+		{query: "@recent", expected: []string{"ideas.md"}},
+		{query: "modified:7d", expected: []string{"ideas.md"}},
+	}
+
+	for _, td := range tests {
+		q, err := services.ParseQuery(td.query, saved)
+		if err != nil {
+			t.Errorf("ParseQuery(%v) failed: %v", td.query, err)
+			continue
+		}
+
+		got := []string{}
+		for _, n := range q.Select(nodes) {
+			got = append(got, n.Title)
+		}
+
+		if !reflect.DeepEqual(got, td.expected) {
+			t.Errorf("Query(%v) sum is different, Want: %v | Got: %v", td.query, td.expected, got)
+		}
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	saved := map[string]string{"loop": "tag:a OR @loop"}
+
+	tests := []struct {
+		query  string
+		reason string
+	}{
+		{query: "", reason: "query is empty"},
+		{query: "tag:a AND", reason: "unexpected end of query"},
+		{query: "(tag:a OR tag:b", reason: "missing closing parenthesis"},
+		{query: "tag:a)", reason: `unexpected ")"`},
+		{query: "OR tag:a", reason: `unexpected "OR"`},
+		{query: "title:\"unclosed", reason: "missing closing quote"},
+		{query: "modified>yesterday", reason: "isn't a valid date"},
+		{query: "size>big", reason: "isn't a valid size"},
+		{query: "type:link", reason: "type must be"},
+		{query: "tag>a", reason: "cannot be compared"},
+		{query: "tag:", reason: "missing value"},
+		{query: "title!draft", reason: "unknown operator"},
+		{query: "@missing", reason: "doesn't exist"},
+		{query: "@loop", reason: "refers to itself"},
+	}
+
+	for _, td := range tests {
+		_, err := services.ParseQuery(td.query, saved)
+		if err == nil || !strings.Contains(err.Error(), td.reason) {
+			t.Errorf("ParseQuery(%v) error is different, Want: %v | Got: %v", td.query, td.reason, err)
+		}
+	}
+}
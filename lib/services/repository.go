@@ -7,9 +7,18 @@
 package services
 
 import (
-	"os"
+	"context"
+	"sort"
+	"strings"
+	"time"
 
+	"cloud.google.com/go/firestore"
+	"github.com/insolite-dev/nt/assets"
 	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/pkg"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -41,12 +50,243 @@ func (s *ServiceType) ToStr() string {
 	return "undefined"
 }
 
+// reachabilityTimeout is the limit of time, to wait for firebase response.
+const reachabilityTimeout = 10 * time.Second
+
 // IsFirebaseEnabled checks if firebase connection is enabled or not.
-func IsFirebaseEnabled(s models.Settings, local *ServiceRepo) bool {
-	stargs := models.StdArgs{Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr}
-	err := NewFirebaseService(stargs, *local).Init(&s)
+// Instead of initializing the whole firebase app, only the settings
+// document of collection is requested via a short-lived firestore client.
+func IsFirebaseEnabled(s models.Settings) bool {
+	if len(s.FirebaseProjectID) == 0 || !pkg.FileExists(s.FirebaseAccountKey) {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), reachabilityTimeout)
+	defer cancel()
+
+	client, err := firestore.NewClient(ctx, s.FirebaseProjectID, option.WithCredentialsFile(s.FirebaseAccountKey))
+	if err != nil {
+		return false
+	}
+	defer client.Close()
+
+	_, err = client.Collection(s.Name).Doc(models.SettingsName).Get(ctx)
+	return err == nil || status.Code(err) == codes.NotFound
+}
+
+// HashKey normalizes the title of node, to use it as a key of hashes map.
+// So, "todo/" and "todo" would point to the same folder.
+func HashKey(title string) string {
+	return strings.Trim(title, "/")
+}
+
+// ignorePatterns merges [ignore] patterns of caller,
+// with the user defined ignore patterns of [config].
+func ignorePatterns(config models.Settings, ignore []string) []string {
+	return append(append([]string{}, ignore...), config.Ignore...)
+}
+
+// SelectNodes filters [nodes] by given path [patterns](see [pkg.MatchPath]).
+// Parent folders of selected nodes are kept too, to be able to create
+// selected nodes at the target service. Empty patterns select all nodes.
+func SelectNodes(nodes []models.Node, patterns []string) []models.Node {
+	if len(patterns) == 0 {
+		return nodes
+	}
+
+	parents := map[string]bool{}
+	for _, node := range nodes {
+		if !pkg.MatchPath(node.Title, patterns) {
+			continue
+		}
+
+		segments := strings.Split(HashKey(node.Title), "/")
+		for i := 1; i < len(segments); i++ {
+			parents[strings.Join(segments[:i], "/")] = true
+		}
+	}
+
+	selected := []models.Node{}
+	for _, node := range nodes {
+		if pkg.MatchPath(node.Title, patterns) || (node.IsFolder() && parents[HashKey(node.Title)]) {
+			selected = append(selected, node)
+		}
+	}
 
-	return err == nil
+	return selected
+}
+
+// PatternsPrefix returns the deepest plain folder, that contains all nodes selected
+// by path [patterns](see [SelectNodes]). Only segments before the first wildcard
+// are considered, and the last segment of pattern is a folder only if pattern
+// ends with "/". Empty prefix means that patterns have no common folder.
+//
+//	Example: ["work/2026-*", "work/todo.md"] → "work"
+func PatternsPrefix(patterns []string) string {
+	var prefix []string
+	for i, pattern := range patterns {
+		segments := strings.Split(strings.Trim(pattern, "/"), "/")
+		if !strings.HasSuffix(pattern, "/") {
+			segments = segments[:len(segments)-1]
+		}
+
+		literal := []string{}
+		for _, segment := range segments {
+			if len(segment) == 0 || strings.ContainsAny(segment, `*?[\`) {
+				break
+			}
+
+			literal = append(literal, segment)
+		}
+
+		if i == 0 {
+			prefix = literal
+			continue
+		}
+
+		n := 0
+		for n < len(prefix) && n < len(literal) && prefix[n] == literal[n] {
+			n++
+		}
+
+		prefix = prefix[:n]
+	}
+
+	return strings.Join(prefix, "/")
+}
+
+// ListSelected lists the nodes of [service], that're selected by path [patterns].
+// Only the common folder of patterns(see [PatternsPrefix]) is listed, so remote
+// services download less data than listing all nodes and filtering them.
+func ListSelected(service ServiceRepo, patterns, ignore []string) ([]models.Node, error) {
+	prefix := PatternsPrefix(patterns)
+	if len(prefix) == 0 {
+		nodes, _, err := service.GetAll("", "", ignore)
+		return SelectNodes(nodes, patterns), err
+	}
+
+	exists, err := service.IsNodeExists(models.Node{Type: models.FOLDER, Title: prefix + "/"})
+	if err != nil || !exists {
+		return []models.Node{}, err
+	}
+
+	// Listed nodes don't include the common folder and its parents,
+	// which are required to create selected nodes at the target service.
+	nodes := []models.Node{}
+	segments := strings.Split(prefix, "/")
+	for i := range segments {
+		nodes = append(nodes, models.Node{Type: models.FOLDER, Title: strings.Join(segments[:i+1], "/") + "/"})
+	}
+
+	listed, _, err := service.GetAll(prefix, "", ignore)
+	if err != nil && err.Error() != assets.EmptyWorkingDirectory.Error() {
+		return nil, err
+	}
+
+	return SelectNodes(append(nodes, listed...), patterns), nil
+}
+
+// ClearSelected removes nodes of [service] that're selected by [patterns].
+// Unlike [SelectNodes], parents of selected nodes are kept. Empty patterns
+// remove all nodes, like [ClearNodes] does.
+func ClearSelected(service ServiceRepo, patterns []string) ([]models.Node, []error) {
+	if len(patterns) == 0 {
+		return service.ClearNodes()
+	}
+
+	nodes, err := ListSelected(service, patterns, models.NotyaIgnoreFiles)
+	if err != nil && err.Error() != assets.EmptyWorkingDirectory.Error() {
+		return nil, []error{err}
+	}
+
+	// Sort nodes via title-len decreasing order, to remove sub nodes first.
+	sort.Slice(
+		nodes,
+		func(i, j int) bool { return len(nodes[i].Title) > len(nodes[j].Title) },
+	)
+
+	var res []models.Node
+	var errs []error
+
+	for _, n := range nodes {
+		if !pkg.MatchPath(n.Title, patterns) {
+			continue
+		}
+
+		if err := service.Remove(n); err != nil {
+			errs = append(errs, assets.CannotDoSth("remove", n.Title, err))
+			continue
+		}
+
+		res = append(res, n)
+	}
+
+	return res, errs
+}
+
+// SelectTagged filters the notes of [nodes], that have all of given [tags](see [models.HasTag]).
+// Folders are skipped, and empty tags select all nodes.
+func SelectTagged(nodes []models.Node, tags []string) []models.Node {
+	if len(tags) == 0 {
+		return nodes
+	}
+
+	selected := []models.Node{}
+	for _, node := range nodes {
+		if !node.IsFile() {
+			continue
+		}
+
+		nodeTags, tagged := node.Tags(), true
+		for _, tag := range tags {
+			tagged = tagged && models.HasTag(nodeTags, tag)
+		}
+
+		if tagged {
+			selected = append(selected, node)
+		}
+	}
+
+	return selected
+}
+
+// CountTags counts the notes of each tag, at given [nodes].
+func CountTags(nodes []models.Node) map[string]int {
+	counts := map[string]int{}
+	for _, node := range nodes {
+		for _, tag := range node.Tags() {
+			counts[strings.ToLower(tag)]++
+		}
+	}
+
+	return counts
+}
+
+// TaggedPatterns generates the exact path patterns of the notes of [service],
+// that are selected by [patterns] and have all of given [tags]. So, tagged
+// notes could be selected by commands, that accept path patterns(fetch, push ...).
+func TaggedPatterns(service ServiceRepo, patterns, tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return patterns, nil
+	}
+
+	nodes, _, err := service.GetAll("", "file", models.NotyaIgnoreFiles)
+	if err != nil && err.Error() != assets.EmptyWorkingDirectory.Error() {
+		return nil, err
+	}
+
+	res := []string{}
+	for _, node := range SelectTagged(nodes, tags) {
+		if pkg.MatchPath(node.Title, patterns) {
+			res = append(res, pkg.EscapePattern(HashKey(node.Title)))
+		}
+	}
+
+	if len(res) == 0 {
+		return nil, assets.NoTaggedNotes
+	}
+
+	return res, nil
 }
 
 // ServiceRepo is a abstract class for all service implementations.
@@ -108,6 +348,22 @@ type ServiceRepo interface {
 	// [typ] provides a way to get only specific type of file-nodes.
 	GetAll(additional, typ string, ignore []string) ([]models.Node, []string, error)
 
+	// GetChanged gets the nodes that were modified after [since].
+	// Zero valued [since] results a full scan, like GetAll does.
+	//
+	// Second returned value is the new high-water mark, that
+	// should be used as [since] for the next call.
+	GetChanged(since time.Time, ignore []string) ([]models.Node, time.Time, error)
+
+	// GetHashes gets content hashes of all nodes, without transferring their bodies.
+	// Result is keyed by [HashKey] of node titles. Folders and nodes without
+	// a stored hash are represented by an empty hash.
+	GetHashes(ignore []string) (map[string]string, error)
+
+	// GetNodes gets type, title and content hash of all nodes, without transferring their bodies.
+	// Result is keyed by [HashKey] of node titles, like [GetHashes].
+	GetNodes(ignore []string) (map[string]models.Node, error)
+
 	Create(note models.Note) (*models.Note, error)
 	View(note models.Note) (*models.Note, error)
 	Edit(note models.Note) (*models.Note, error)
@@ -123,12 +379,19 @@ type ServiceRepo interface {
 
 	// Fetch fetches nodes(that doesn't exists
 	// on current service) from remote service to local service.
-	Fetch(remote ServiceRepo) ([]models.Node, []error)
+	// Only the nodes selected by [patterns] are fetched, see [SelectNodes].
+	Fetch(remote ServiceRepo, patterns []string) ([]models.Node, []error)
 
 	// Push uploads all notes from local service to provided remote.
-	Push(remote ServiceRepo) ([]models.Node, []error)
+	// Only the nodes selected by [patterns] are pushed, see [SelectNodes].
+	Push(remote ServiceRepo, patterns []string) ([]models.Node, []error)
 
 	// Migrate clones current service data to [remote] service data.
 	// [remote] service data would be cleared and replaced with current service data.
-	Migrate(remote ServiceRepo) ([]models.Node, []error)
+	// If [patterns] are provided, only the selected nodes are cleared and replaced.
+	Migrate(remote ServiceRepo, patterns []string) ([]models.Node, []error)
+
+	// Doctor detects inconsistencies of service's data.
+	// If [fix] is true, found problems are repaired when it's possible.
+	Doctor(fix bool) (*models.DoctorReport, error)
 }
@@ -7,8 +7,10 @@
 package services_test
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/insolite-dev/nt/lib/models"
 	"github.com/insolite-dev/nt/lib/services"
 )
 
@@ -29,3 +31,121 @@ func TestServiceTypeToStr(t *testing.T) {
 		}
 	}
 }
+
+func TestHashKey(t *testing.T) {
+	tests := []struct {
+		title    string
+		expected string
+	}{
+		{title: "note.md", expected: "note.md"},
+		{title: "todo/", expected: "todo"},
+		{title: "/todo/today.md", expected: "todo/today.md"},
+	}
+
+	for _, td := range tests {
+		got := services.HashKey(td.title)
+		if got != td.expected {
+			t.Errorf("HashKey sum is different, Want: %v | Got: %v", td.expected, got)
+		}
+	}
+}
+
+func TestSelectTagged(t *testing.T) {
+	nodes := []models.Node{
+		{Type: models.FOLDER, Title: "work/"},
+		{Type: models.FILE, Title: "work/todo.md", Body: "---\ntags: [work, Urgent]\n---\n- [ ] release"},
+		{Type: models.FILE, Title: "work/ideas.md", Body: "Some #work/project ideas"},
+		{Type: models.FILE, Title: "journal.md", Body: "# Today\n#urgent call"},
+	}
+
+	tests := []struct {
+		tags     []string
+		expected []string
+	}{
+		{tags: nil, expected: []string{"work/", "work/todo.md", "work/ideas.md", "journal.md"}},
+		{tags: []string{"work"}, expected: []string{"work/todo.md", "work/ideas.md"}},
+		{tags: []string{"#urgent"}, expected: []string{"work/todo.md", "journal.md"}},
+		{tags: []string{"work", "urgent"}, expected: []string{"work/todo.md"}},
+		{tags: []string{"missing"}, expected: []string{}},
+	}
+
+	for _, td := range tests {
+		got := []string{}
+		for _, n := range services.SelectTagged(nodes, td.tags) {
+			got = append(got, n.Title)
+		}
+
+		if !reflect.DeepEqual(got, td.expected) {
+			t.Errorf("SelectTagged(%v) sum is different, Want: %v | Got: %v", td.tags, td.expected, got)
+		}
+	}
+
+	expectedCounts := map[string]int{"work": 1, "urgent": 2, "work/project": 1}
+	if got := services.CountTags(nodes); !reflect.DeepEqual(got, expectedCounts) {
+		t.Errorf("CountTags sum is different, Want: %v | Got: %v", expectedCounts, got)
+	}
+}
+
+func TestSelectNodes(t *testing.T) {
+	nodes := []models.Node{
+		{Type: models.FOLDER, Title: "journal/"},
+		{Type: models.FILE, Title: "journal/2025-12-31.md"},
+		{Type: models.FILE, Title: "journal/2026-01-01.md"},
+		{Type: models.FOLDER, Title: "private/"},
+		{Type: models.FILE, Title: "private/secret.md"},
+		{Type: models.FOLDER, Title: "work/"},
+		{Type: models.FILE, Title: "work/todo.md"},
+	}
+
+	tests := []struct {
+		patterns []string
+		expected []string
+	}{
+		{
+			patterns: nil,
+			expected: []string{"journal/", "journal/2025-12-31.md", "journal/2026-01-01.md", "private/", "private/secret.md", "work/", "work/todo.md"},
+		},
+		{
+			patterns: []string{"work/"},
+			expected: []string{"work/", "work/todo.md"},
+		},
+		{
+			patterns: []string{"work/", "journal/2026-*"},
+			expected: []string{"journal/", "journal/2026-01-01.md", "work/", "work/todo.md"},
+		},
+	}
+
+	for _, td := range tests {
+		got := []string{}
+		for _, n := range services.SelectNodes(nodes, td.patterns) {
+			got = append(got, n.Title)
+		}
+
+		if !reflect.DeepEqual(got, td.expected) {
+			t.Errorf("SelectNodes(%v) sum is different, Want: %v | Got: %v", td.patterns, td.expected, got)
+		}
+	}
+}
+
+func TestPatternsPrefix(t *testing.T) {
+	tests := []struct {
+		patterns []string
+		expected string
+	}{
+		{patterns: nil, expected: ""},
+		{patterns: []string{"work/"}, expected: "work"},
+		{patterns: []string{"work/todo.md"}, expected: "work"},
+		{patterns: []string{"todo.md"}, expected: ""},
+		{patterns: []string{"journal/2026/*"}, expected: "journal/2026"},
+		{patterns: []string{"journal/2026-*/notes/"}, expected: "journal"},
+		{patterns: []string{"work/a/", "work/b/todo.md"}, expected: "work"},
+		{patterns: []string{"work/", "journal/2026-*"}, expected: ""},
+	}
+
+	for _, td := range tests {
+		got := services.PatternsPrefix(td.patterns)
+		if got != td.expected {
+			t.Errorf("PatternsPrefix(%v) sum is different, Want: %v | Got: %v", td.patterns, td.expected, got)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/insolite-dev/nt/assets"
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/pkg"
+)
+
+// Default periods of sync daemon.
+const (
+	DefaultSyncDebounce = 2 * time.Second
+	DefaultSyncInterval = time.Minute
+)
+
+// Syncer keeps the local service in sync with a remote service.
+// Local changes are pushed after they settle down, and remote
+// changes are fetched periodically. Uses the [Push] and [Fetch]
+// of local service, so only changed nodes are transferred.
+type Syncer struct {
+	Local  ServiceRepo
+	Remote ServiceRepo
+
+	// Debounce is the quiet period after the last local change, before pushing.
+	Debounce time.Duration
+
+	// Interval is the period of fetching remote changes.
+	Interval time.Duration
+
+	// Tags limits syncing to the notes, that have all of these tags.
+	Tags []string
+
+	// Logger is the destination of sync logs.
+	Logger *log.Logger
+
+	// Status is the current state of syncer, that's written to status file.
+	Status models.SyncStatus
+}
+
+// NewSyncer creates new syncer with default periods.
+func NewSyncer(local, remote ServiceRepo, logger *log.Logger) *Syncer {
+	return &Syncer{
+		Local:    local,
+		Remote:   remote,
+		Debounce: DefaultSyncDebounce,
+		Interval: DefaultSyncInterval,
+		Logger:   logger,
+		Status:   models.SyncStatus{Remote: remote.Type()},
+	}
+}
+
+// SyncStatusPath generates the path of sync status file of [local] service.
+func SyncStatusPath(local ServiceRepo) string {
+	base, _ := local.Path()
+	return base + models.SyncStatusName
+}
+
+// ReadSyncStatus reads the last written status of sync daemon.
+func ReadSyncStatus(local ServiceRepo) (*models.SyncStatus, error) {
+	data, err := pkg.ReadBody(SyncStatusPath(local))
+	if err != nil {
+		return nil, err
+	}
+
+	status := models.DecodeSyncStatus(*data)
+	return &status, nil
+}
+
+// writeStatus overwrites the sync status file with current status.
+// Written status also reports that the daemon is alive, see [models.SyncStatus.IsRunning].
+func (s *Syncer) writeStatus() {
+	s.Status.AliveAt = time.Now()
+	if err := pkg.WriteNote(SyncStatusPath(s.Local), s.Status.ToString()); err != nil {
+		s.Logger.Printf("cannot write status | %v", err)
+	}
+}
+
+// Push pushes local changes to remote, and records the result to status.
+func (s *Syncer) Push() []error {
+	var pushed []models.Node
+
+	patterns, errs := s.tagged(s.Local)
+	if len(errs) == 0 && patterns != nil {
+		pushed, errs = s.Local.Push(s.Remote, patterns)
+	}
+
+	s.record("push", pushed, errs)
+
+	s.Status.LastPush = time.Now()
+	s.Status.Pushed += len(pushed)
+	s.writeStatus()
+
+	return errs
+}
+
+// Fetch fetches remote changes to local, and records the result to status.
+func (s *Syncer) Fetch() []error {
+	var fetched []models.Node
+
+	patterns, errs := s.tagged(s.Remote)
+	if len(errs) == 0 && patterns != nil {
+		fetched, errs = s.Local.Fetch(s.Remote, patterns)
+	}
+
+	s.record("fetch", fetched, errs)
+
+	s.Status.LastFetch = time.Now()
+	s.Status.Fetched += len(fetched)
+	s.writeStatus()
+
+	return errs
+}
+
+// tagged generates the patterns of tagged notes of [service], see [TaggedPatterns].
+// Nil patterns mean there is nothing to sync. Without [s.Tags] all notes are selected.
+func (s *Syncer) tagged(service ServiceRepo) ([]string, []error) {
+	if len(s.Tags) == 0 {
+		return []string{}, nil
+	}
+
+	patterns, err := TaggedPatterns(service, nil, s.Tags)
+	if err == assets.NoTaggedNotes {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	return patterns, nil
+}
+
+// Sync pushes local changes, and then fetches remote changes.
+func (s *Syncer) Sync() []error {
+	return append(s.Push(), s.Fetch()...)
+}
+
+// record logs the result of [act], and saves its last error to status.
+func (s *Syncer) record(act string, nodes []models.Node, errs []error) {
+	for _, n := range nodes {
+		s.Logger.Printf("%v %v", act, n.Title)
+	}
+
+	for _, err := range errs {
+		s.Logger.Printf("cannot %v | %v", act, err)
+		s.Status.LastError = err.Error()
+	}
+
+	if fire, ok := s.Remote.(*FirebaseService); ok {
+		for _, w := range fire.Warnings {
+			s.Logger.Printf("warning | %v", w)
+		}
+
+		fire.Warnings = nil
+	}
+}
+
+// Watch runs the sync daemon until [ctx] is done.
+// Notes directory is watched recursively, and changes are pushed after
+// [s.Debounce] of quietness. Remote changes are fetched every [s.Interval],
+// unless there are local changes waiting to be pushed.
+func (s *Syncer) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	_, notesPath := s.Local.Path()
+	if err := s.watchDir(watcher, notesPath); err != nil {
+		return err
+	}
+
+	s.Status.PID = os.Getpid()
+	s.Status.StartedAt = time.Now()
+	s.Status.Interval = s.Interval
+	s.Logger.Printf("watching %v, syncing with %v", notesPath, s.Remote.Type())
+
+	s.Sync()
+
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	debounce := time.NewTimer(s.Debounce)
+	debounce.Stop()
+
+	// pending represents whether there are local changes, that aren't pushed yet.
+	pending := false
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.Status.StoppedAt = time.Now()
+			s.writeStatus()
+			s.Logger.Printf("stopped")
+
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			if s.isIgnorable(notesPath, event.Name) {
+				continue
+			}
+
+			if event.Op&fsnotify.Create != 0 && pkg.IsDir(event.Name) {
+				if err := s.watchDir(watcher, event.Name); err != nil {
+					s.Logger.Printf("cannot watch %v | %v", event.Name, err)
+				}
+			}
+
+			// Restart quiet period of local changes.
+			if !debounce.Stop() {
+				select {
+				case <-debounce.C:
+				default:
+				}
+			}
+			debounce.Reset(s.Debounce)
+			pending = true
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+
+			s.Logger.Printf("watcher error | %v", err)
+
+		case <-debounce.C:
+			pending = false
+			s.Push()
+
+		case <-ticker.C:
+			// Local changes are pushed first, fetching is postponed to the next tick.
+			if pending {
+				s.writeStatus()
+				continue
+			}
+
+			s.Fetch()
+		}
+	}
+}
+
+// watchDir adds [dir] and its sub folders to [watcher], by skipping ignorable ones.
+func (s *Syncer) watchDir(watcher *fsnotify.Watcher, dir string) error {
+	_, notesPath := s.Local.Path()
+
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() {
+			return nil
+		}
+
+		if path != dir && s.isIgnorable(notesPath, path) {
+			return filepath.SkipDir
+		}
+
+		return watcher.Add(path)
+	})
+}
+
+// isIgnorable checks if the file at [path] shouldn't trigger syncing.
+// Ignorable files, contents of ignorable folders, temporary notes and
+// nodes ignored by ignore patterns(or files) of local service are skipped.
+func (s *Syncer) isIgnorable(notesPath, path string) bool {
+	rel := strings.Trim(strings.TrimPrefix(path, notesPath), "/")
+	if len(rel) == 0 {
+		return false
+	}
+
+	for _, segment := range strings.Split(rel, "/") {
+		if pkg.IsIgnorable(segment, models.NotyaIgnoreFiles) || pkg.IsTempTitle(segment) {
+			return true
+		}
+	}
+
+	if local, ok := s.Local.(*LocalService); ok {
+		return local.IsIgnored(rel, pkg.IsDir(path))
+	}
+
+	return false
+}
@@ -0,0 +1,50 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/insolite-dev/nt/assets"
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/pkg"
+)
+
+// FindTemplate fetches the template of [name] from the [models.TemplatesFolder] of [service].
+// Templates are looked up like wiki links, so name could omit the extension of template.
+func FindTemplate(service ServiceRepo, name string) (*models.Note, error) {
+	templates, err := ListSelected(service, []string{models.TemplatesFolder + "/"}, models.NotyaIgnoreFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve name relative to the templates folder, like a link written at a template.
+	link := models.Link{Kind: models.WikiLink, Source: models.TemplatesFolder + "/.", Target: strings.TrimSpace(name)}
+	title := NewLinkIndex(templates).Resolve(link)
+	if len(title) == 0 {
+		return nil, assets.NotExists(path.Join(models.TemplatesFolder, name), "Template")
+	}
+
+	return service.View(models.Note{Title: title})
+}
+
+// TemplateValues generates the values of built-in template variables, for the note of [title].
+func TemplateValues(title string, now time.Time) map[string]string {
+	title = HashKey(title)
+	name := path.Base(title)
+
+	return map[string]string{
+		models.DateVariable:     now.Format("2006-01-02"),
+		models.TimeVariable:     now.Format("15:04"),
+		models.DateTimeVariable: now.Format("2006-01-02 15:04"),
+		models.TitleVariable:    strings.TrimSuffix(name, path.Ext(name)),
+		models.PathVariable:     title,
+		models.UserVariable:     pkg.Username(),
+	}
+}
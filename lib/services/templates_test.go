@@ -0,0 +1,38 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/lib/services"
+)
+
+func TestTemplateValues(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 5, 0, 0, time.Local)
+	values := services.TemplateValues("/work/meetings/standup.md", now)
+
+	expected := map[string]string{
+		models.DateVariable:     "2026-10-19",
+		models.TimeVariable:     "09:05",
+		models.DateTimeVariable: "2026-10-19 09:05",
+		models.TitleVariable:    "standup",
+		models.PathVariable:     "work/meetings/standup.md",
+	}
+
+	for name, value := range expected {
+		if values[name] != value {
+			t.Errorf("TemplateValues[%v] was different: Want: %v | Got: %v", name, value, values[name])
+		}
+	}
+
+	if len(values[models.UserVariable]) == 0 {
+		t.Errorf("TemplateValues must contain the user")
+	}
+}
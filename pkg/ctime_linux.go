@@ -0,0 +1,28 @@
+//go:build linux
+// +build linux
+
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package pkg
+
+import (
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// CreationTime returns the birth time of file at [path].
+// Falls back to the modification time, if file system doesn't record it.
+func CreationTime(path string, info os.FileInfo) time.Time {
+	var stat unix.Statx_t
+	if err := unix.Statx(unix.AT_FDCWD, path, 0, unix.STATX_BTIME, &stat); err != nil || stat.Mask&unix.STATX_BTIME == 0 {
+		return info.ModTime()
+	}
+
+	return time.Unix(stat.Btime.Sec, int64(stat.Btime.Nsec))
+}
@@ -0,0 +1,21 @@
+//go:build !linux && !darwin && !windows
+// +build !linux,!darwin,!windows
+
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package pkg
+
+import (
+	"os"
+	"time"
+)
+
+// CreationTime returns the birth time of file at [path].
+// Birth time isn't available on this platform, so modification time is used.
+func CreationTime(path string, info os.FileInfo) time.Time {
+	return info.ModTime()
+}
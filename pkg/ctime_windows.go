@@ -0,0 +1,27 @@
+//go:build windows
+// +build windows
+
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package pkg
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// CreationTime returns the birth time of file at [path].
+// Falls back to the modification time, if file system doesn't record it.
+func CreationTime(path string, info os.FileInfo) time.Time {
+	data, ok := info.Sys().(*syscall.Win32FileAttributeData)
+	if !ok {
+		return info.ModTime()
+	}
+
+	return time.Unix(0, data.CreationTime.Nanoseconds())
+}
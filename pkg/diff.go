@@ -0,0 +1,63 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package pkg
+
+import "strings"
+
+// Diff generates a line based difference of [a] and [b] bodies.
+// Each line of result is prefixed by its state:
+//   - "  " unchanged line.
+//   - "- " line that exists only at [a].
+//   - "+ " line that exists only at [b].
+func Diff(a, b string) []string {
+	x, y := strings.Split(a, "\n"), strings.Split(b, "\n")
+
+	// Generate longest-common-subsequence table of lines, from the end.
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	res := []string{}
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i] == y[j]:
+			res = append(res, "  "+x[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			res = append(res, "- "+x[i])
+			i++
+		default:
+			res = append(res, "+ "+y[j])
+			j++
+		}
+	}
+
+	for ; i < len(x); i++ {
+		res = append(res, "- "+x[i])
+	}
+
+	for ; j < len(y); j++ {
+		res = append(res, "+ "+y[j])
+	}
+
+	return res
+}
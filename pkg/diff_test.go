@@ -0,0 +1,50 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package pkg_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/insolite-dev/nt/pkg"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		testname string
+		a, b     string
+		expected []string
+	}{
+		{
+			testname: "should mark all lines as unchanged",
+			a:        "a\nb",
+			b:        "a\nb",
+			expected: []string{"  a", "  b"},
+		},
+		{
+			testname: "should mark removed and added lines",
+			a:        "a\nb\nc",
+			b:        "a\nx\nc\nd",
+			expected: []string{"  a", "- b", "+ x", "  c", "+ d"},
+		},
+		{
+			testname: "should mark all lines of empty body as changed",
+			a:        "",
+			b:        "a",
+			expected: []string{"- ", "+ a"},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testname, func(t *testing.T) {
+			got := pkg.Diff(td.a, td.b)
+			if !reflect.DeepEqual(got, td.expected) {
+				t.Errorf("Diff sum was different: Want: %v | Got: %v", td.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package pkg
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/insolite-dev/nt/lib/models"
+)
+
+// IgnoreRule is a single gitignore-style pattern, that's
+// defined at a concrete folder(or root) of notes.
+type IgnoreRule struct {
+	// Base is the folder title, that rule is defined at.
+	// Rule applies only to the nodes under base. Empty base is the root.
+	Base string
+
+	// Pattern is the raw pattern of rule.
+	Pattern string
+
+	// Negate re-includes the matched nodes, that were ignored by previous rules.
+	Negate bool
+
+	// DirOnly makes rule match only folders.
+	DirOnly bool
+
+	re *regexp.Regexp
+}
+
+// IgnoreRules is an ordered list of ignore rules, where the last matching rule wins.
+type IgnoreRules []IgnoreRule
+
+// ParseIgnore parses gitignore-style [lines], that're defined at [base] folder.
+//
+// Supported syntax:
+//   - blank lines and lines starting with "#" are skipped.
+//   - "!" prefix negates the pattern.
+//   - "/" suffix matches only folders.
+//   - a pattern with "/" at the start or middle is relative to [base],
+//     otherwise it matches a node name at any level under [base].
+//   - "*", "?", "[...]" and "**" wildcards.
+func ParseIgnore(base string, lines []string) IgnoreRules {
+	base = strings.Trim(base, "/")
+	if len(base) > 0 {
+		base += "/"
+	}
+
+	rules := IgnoreRules{}
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := IgnoreRule{Base: base, Pattern: line}
+		if strings.HasPrefix(line, "!") {
+			rule.Negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\#`) || strings.HasPrefix(line, `\!`) {
+			line = line[1:]
+		}
+
+		if strings.HasSuffix(line, "/") {
+			rule.DirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+
+		if len(line) == 0 {
+			continue
+		}
+
+		prefix := "^(?:.*/)?"
+		if strings.Contains(line, "/") {
+			prefix = "^"
+			line = strings.TrimPrefix(line, "/")
+		}
+
+		re, err := regexp.Compile(prefix + globToRegexp(line) + "$")
+		if err != nil {
+			continue
+		}
+
+		rule.re = re
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+// globToRegexp converts gitignore glob to regular expression.
+func globToRegexp(glob string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(glob):
+			i++
+			b.WriteString(regexp.QuoteMeta(string(glob[i])))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	return b.String()
+}
+
+// Match checks if the node of [title] is ignored by rules.
+// Like git does, nodes under an ignored folder are always ignored,
+// and can't be re-included by a negated rule.
+func (rules IgnoreRules) Match(title string, isDir bool) bool {
+	title = strings.Trim(title, "/")
+	if len(title) == 0 {
+		return false
+	}
+
+	segments := strings.Split(title, "/")
+	for i := 1; i < len(segments); i++ {
+		if rules.matchOne(strings.Join(segments[:i], "/"), true) {
+			return true
+		}
+	}
+
+	return rules.matchOne(title, isDir)
+}
+
+// matchOne checks if the exact [title] is ignored by rules, without checking its parents.
+func (rules IgnoreRules) matchOne(title string, isDir bool) bool {
+	ignored := false
+	for _, rule := range rules {
+		if rule.DirOnly && !isDir {
+			continue
+		}
+
+		if !strings.HasPrefix(title, rule.Base) {
+			continue
+		}
+
+		if rule.re.MatchString(strings.TrimPrefix(title, rule.Base)) {
+			ignored = !rule.Negate
+		}
+	}
+
+	return ignored
+}
+
+// ReadIgnore reads the ignore file of [dir] folder(which is relative to [root]),
+// and parses it as rules of that folder. Missing file results no rules.
+func ReadIgnore(root, dir string) IgnoreRules {
+	if len(root) > 0 && root[len(root)-1] != '/' {
+		root += "/"
+	}
+
+	folder := strings.Trim(dir, "/")
+	if len(folder) > 0 {
+		folder += "/"
+	}
+
+	body, err := ReadBody(root + folder + models.IgnoreFileName)
+	if err != nil {
+		return IgnoreRules{}
+	}
+
+	return ParseIgnore(folder, strings.Split(*body, "\n"))
+}
+
+// LoadIgnore collects rules of [patterns] and ignore files of root and
+// all parent folders of [title], which are placed at [root] directory.
+func LoadIgnore(root, title string, patterns []string) IgnoreRules {
+	rules := append(ParseIgnore("", patterns), ReadIgnore(root, "")...)
+
+	segments := strings.Split(strings.Trim(title, "/"), "/")
+	for i := 1; i < len(segments); i++ {
+		rules = append(rules, ReadIgnore(root, strings.Join(segments[:i], "/"))...)
+	}
+
+	return rules
+}
@@ -0,0 +1,111 @@
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package pkg_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/insolite-dev/nt/lib/models"
+	"github.com/insolite-dev/nt/pkg"
+)
+
+func TestIgnoreRulesMatch(t *testing.T) {
+	rules := append(
+		pkg.ParseIgnore("", []string{
+			"# editor files",
+			"*.swp",
+			"*.tmp",
+			"!keep.tmp",
+			"node_modules/",
+			"/build",
+			"docs/**/draft-*",
+			"",
+		}),
+		pkg.ParseIgnore("work/", []string{"secret.md", "/local/"})...,
+	)
+
+	tests := []struct {
+		title    string
+		isDir    bool
+		expected bool
+	}{
+		{title: "note.md", expected: false},
+		{title: "note.md.swp", expected: true},
+		{title: "todo/.today.md.swp", expected: true},
+		{title: "cache.tmp", expected: true},
+		{title: "keep.tmp", expected: false},
+		{title: "node_modules/", isDir: true, expected: true},
+		{title: "app/node_modules/lib/index.md", expected: true},
+		{title: "node_modules", isDir: false, expected: false},
+		{title: "build/", isDir: true, expected: true},
+		{title: "app/build/", isDir: true, expected: false},
+		{title: "docs/draft-1.md", expected: true},
+		{title: "docs/2026/draft-2.md", expected: true},
+		{title: "docs/final.md", expected: false},
+		{title: "work/secret.md", expected: true},
+		{title: "work/projects/secret.md", expected: true},
+		{title: "secret.md", expected: false},
+		{title: "work/local/x.md", expected: true},
+		{title: "work/projects/local/x.md", expected: false},
+	}
+
+	for _, td := range tests {
+		got := rules.Match(td.title, td.isDir)
+		if got != td.expected {
+			t.Errorf("Sum of IgnoreRules.Match(%v) was different: Want: %v | Got: %v", td.title, td.expected, got)
+		}
+	}
+}
+
+func TestListDirWithIgnoreFiles(t *testing.T) {
+	root := t.TempDir() + "/"
+
+	files := map[string]string{
+		models.IgnoreFileName:           "*.tmp\n",
+		"a.md":                          "",
+		"b.tmp":                         "",
+		"work/" + models.IgnoreFileName: "private/\n",
+		"work/c.md":                     "",
+		"work/private/d.md":             "",
+		"other/private/e.md":            "",
+		"other/debug.log":               "",
+	}
+
+	for name, body := range files {
+		_ = os.MkdirAll(filepath.Dir(root+name), 0o750)
+		_ = os.WriteFile(root+name, []byte(body), 0o600)
+	}
+
+	got, _, err := pkg.ListDir(root, root, "file", []string{"*.log"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]bool{
+		models.IgnoreFileName:           true,
+		"a.md":                          true,
+		"work/" + models.IgnoreFileName: true,
+		"work/c.md":                     true,
+		"other/private/e.md":            true,
+	}
+
+	gotSet := map[string]bool{}
+	for _, f := range got {
+		gotSet[f] = true
+	}
+
+	if !reflect.DeepEqual(gotSet, expected) {
+		t.Errorf("Sum of ListDir was different: Want: %v | Got: %v", expected, gotSet)
+	}
+
+	if rules := pkg.LoadIgnore(root, "work/private/d.md", nil); !rules.Match("work/private/d.md", false) {
+		t.Errorf("LoadIgnore must load rules of parent folders")
+	}
+}
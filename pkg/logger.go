@@ -9,6 +9,8 @@ package pkg
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/briandowns/spinner"
@@ -143,6 +145,18 @@ func PrintNote(note models.Note, service string) {
 		text.Println(path)
 	}
 
+	if !note.UpdatedAt.IsZero() {
+		modified := fmt.Sprintf("%v %v",
+			fmt.Sprintf("%s%s%s", PURPLE, "Modified:", NOCOLOR),
+			fmt.Sprintf("%s%s%s", GREY, FormatTime(note.UpdatedAt), NOCOLOR),
+		)
+		if len(note.UpdatedBy) > 0 {
+			modified += fmt.Sprintf(" %sby %v%s", GREY, note.UpdatedBy, NOCOLOR)
+		}
+
+		text.Println(modified)
+	}
+
 	// Printout no content if body is empty.
 	if len(note.Body) == 0 {
 		text.Add(color.FgHiYellow).Println("\n No content ... \n ")
@@ -151,6 +165,40 @@ func PrintNote(note models.Note, service string) {
 	}
 }
 
+// PrintNoteMeta, prints given note's metadata, without its body.
+func PrintNoteMeta(note models.Note, service string) {
+	hash := note.Hash
+	if len(hash) == 0 {
+		hash = Hash(note.Body)
+	}
+
+	var tags, aliases string
+	if note.Meta != nil {
+		tags, aliases = strings.Join(note.Meta.Tags, ", "), strings.Join(note.Meta.Aliases, ", ")
+	}
+
+	fields := [][]string{
+		{"Title:", note.Title},
+		{"Aliases:", aliases},
+		{"Tags:", tags},
+		{"Path:", note.Path[service]},
+		{"Hash:", hash},
+		{"Size:", FormatSize(int64(len(note.Body)))},
+		{"Created:", FormatTime(note.CreatedAt)},
+		{"Modified:", FormatTime(note.UpdatedAt)},
+		{"Modified by:", note.UpdatedBy},
+	}
+
+	fmt.Println()
+	for _, f := range fields {
+		if len(f[1]) == 0 {
+			continue
+		}
+
+		text.Println(fmt.Sprintf("%v %v", fmt.Sprintf("%s%s%s", PURPLE, f[0], NOCOLOR), f[1]))
+	}
+}
+
 // PrintNotes, logs given nodes list.
 func PrintNodes(list []models.Node) {
 	if len(list) == 0 {
@@ -168,6 +216,78 @@ func PrintNodes(list []models.Node) {
 	}
 }
 
+// PrintNodeTitles, logs given nodes list by their full titles.
+// Used for filtered nodes, which don't form a tree to be shown via [PrintNodes].
+//
+//	Example:
+//
+//	• todo/today.md
+func PrintNodeTitles(list []models.Node) {
+	for _, value := range list {
+		note := fmt.Sprintf(
+			" %v %v",
+			fmt.Sprintf("%s%s%s", GREY, "•", NOCOLOR),
+			fmt.Sprintf("%s%s%s", DARKYELLOW, value.Title, NOCOLOR),
+		)
+		text.Println(note)
+	}
+}
+
+// PrintNodesLong, logs given nodes list, with their metadata.
+//
+//	Example:
+//
+//	•   1.2 KB  2021-12-08 14:43  john@macbook  todo/today.md
+func PrintNodesLong(list []models.Node) {
+	for _, value := range list {
+		size := ""
+		if value.IsFile() {
+			size = FormatSize(value.Size)
+		}
+
+		modified := ""
+		if !value.UpdatedAt.IsZero() {
+			modified = value.UpdatedAt.Local().Format("2006-01-02 15:04")
+		}
+
+		note := fmt.Sprintf(
+			" %v %s %s %s %v",
+			fmt.Sprintf("%s%s%s", GREY, "•", NOCOLOR),
+			fmt.Sprintf("%s%9s%s", GREY, size, NOCOLOR),
+			fmt.Sprintf("%s%16s%s", GREY, modified, NOCOLOR),
+			fmt.Sprintf("%s%s%s", YELLOW, value.UpdatedBy, NOCOLOR),
+			fmt.Sprintf("%s%s%s", DARKYELLOW, value.Title, NOCOLOR),
+		)
+		text.Println(note)
+	}
+}
+
+// FormatSize converts given byte size to a human readable string, like "1.2 KB".
+func FormatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// FormatTime converts given time to a human readable local time string.
+// Zero time results an empty string.
+func FormatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
 // PrintSettings, logs given settings model.
 func PrintSettings(settings models.Settings) {
 	values := settings.ToJSON()
@@ -190,6 +310,306 @@ func PrintErrors(act string, errs []error) {
 	}
 }
 
+// PrintOperations, logs given queued operations.
+func PrintOperations(ops []models.Operation) {
+	for _, op := range ops {
+		title := op.Title
+		if len(op.NewTitle) > 0 {
+			title += " -> " + op.NewTitle
+		}
+
+		text.Println(fmt.Sprintf(
+			" • %v %v %v",
+			fmt.Sprintf("%s%s%s", GREY, op.ID, NOCOLOR),
+			fmt.Sprintf("%s%s%s", YELLOW, op.Kind, NOCOLOR),
+			title,
+		))
+
+		if len(op.LastError) > 0 {
+			state := "failed"
+			if op.Conflict {
+				state = "conflict"
+			}
+
+			text.Println(fmt.Sprintf("     %s%s(%v attempts): %v%s", RED, state, op.Attempts, op.LastError, NOCOLOR))
+		}
+	}
+}
+
+// PrintDiff, logs given diff lines(generated by [Diff]) with appropriate colors.
+func PrintDiff(lines []string) {
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "- "):
+			text.Println(fmt.Sprintf("%s%s%s", RED, line, NOCOLOR))
+		case strings.HasPrefix(line, "+ "):
+			text.Println(fmt.Sprintf("%s%s%s", GREEN, line, NOCOLOR))
+		default:
+			text.Println(line)
+		}
+	}
+}
+
+// PrintDiagnoses, logs diagnoses of given doctor report.
+func PrintDiagnoses(report models.DoctorReport) {
+	for _, d := range report.Diagnoses {
+		state := fmt.Sprintf("%s%s%s", RED, "[X]", NOCOLOR)
+		if d.Fixed {
+			state = fmt.Sprintf("%s%s%s", GREEN, "[✔]", NOCOLOR)
+		}
+
+		text.Println(fmt.Sprintf(" %v %v %v", state, fmt.Sprintf("%s%s%s", YELLOW, d.Kind, NOCOLOR), d.Path))
+		text.Println(fmt.Sprintf("     %v", d.Message))
+
+		if !d.Fixed && len(d.Suggestion) > 0 {
+			text.Println(fmt.Sprintf("     %s%s%s", GREY, d.Suggestion, NOCOLOR))
+		}
+	}
+}
+
+// PrintRetried, logs given labels of requests, that succeeded after retrying.
+func PrintRetried(labels []string) {
+	text.Println(fmt.Sprintf("%s%s%s", YELLOW, "Succeeded after retrying:", NOCOLOR))
+	for _, l := range labels {
+		text.Println(fmt.Sprintf(" • %v", l))
+	}
+}
+
+// PrintWarnings, logs the warnings of degraded service behaviours.
+func PrintWarnings(warnings []string) {
+	for _, w := range warnings {
+		text.Println(fmt.Sprintf("%s%s%s %v", YELLOW, "WARNING:", NOCOLOR, w))
+	}
+}
+
+// PrintStatus, logs the node statuses of given report, grouped by their states.
+// Identical nodes are logged only if [all] is true.
+func PrintStatus(report models.StatusReport, all bool) {
+	groups := []struct {
+		state string
+		title string
+		color string
+	}{
+		{models.LocalOnlyState, "Only at LOCAL:", GREEN},
+		{models.RemoteOnlyState, fmt.Sprintf("Only at %v:", report.Remote), RED},
+		{models.ModifiedState, "Modified:", YELLOW},
+		{models.IdenticalState, "Identical:", GREY},
+	}
+
+	for _, g := range groups {
+		nodes := report.Filter(g.state)
+		if len(nodes) == 0 || (g.state == models.IdenticalState && !all) {
+			continue
+		}
+
+		text.Println(g.title)
+		for _, n := range nodes {
+			title := n.Title
+			if len(n.Side) > 0 {
+				title = fmt.Sprintf("%v (%v)", title, n.Side)
+			}
+
+			text.Println(fmt.Sprintf("  %s%s%s", g.color, title, NOCOLOR))
+		}
+
+		text.Println()
+	}
+}
+
+// PrintTags, logs given tags with their note counts.
+// Tags are sorted by count, and alphabetically when counts are equal.
+func PrintTags(counts map[string]int) {
+	tags := []string{}
+	for tag := range counts {
+		tags = append(tags, tag)
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		if counts[tags[i]] != counts[tags[j]] {
+			return counts[tags[i]] > counts[tags[j]]
+		}
+
+		return tags[i] < tags[j]
+	})
+
+	for _, tag := range tags {
+		text.Println(fmt.Sprintf(
+			" %v %v %v",
+			fmt.Sprintf("%s%s%s", GREY, "•", NOCOLOR),
+			fmt.Sprintf("%s#%s%s", YELLOW, tag, NOCOLOR),
+			fmt.Sprintf("%s%v%s", GREY, counts[tag], NOCOLOR),
+		))
+	}
+}
+
+// PrintQueries, logs given saved queries, sorted by their names.
+func PrintQueries(queries map[string]string) {
+	names := []string{}
+	for name := range queries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		text.Println(fmt.Sprintf(
+			" %v %v %v",
+			fmt.Sprintf("%s%s%s", GREY, "•", NOCOLOR),
+			fmt.Sprintf("%s@%s%s", YELLOW, name, NOCOLOR),
+			queries[name],
+		))
+	}
+}
+
+// PrintLinks, logs given links between notes.
+// Incoming links are logged by their sources, outgoing ones by their targets.
+func PrintLinks(links []models.Link, incoming bool) {
+	for _, link := range links {
+		target := fmt.Sprintf("%s%s%s", YELLOW, link.Title, NOCOLOR)
+		if link.IsDangling() {
+			target = fmt.Sprintf("%s%s (dangling)%s", RED, link.Target, NOCOLOR)
+		} else if !strings.EqualFold(link.Target, link.Title) {
+			target = fmt.Sprintf("%s %s→ %s%s%s", link.Target, GREY, YELLOW, link.Title, NOCOLOR)
+		}
+
+		location := fmt.Sprintf("%s:%v", link.Source, link.Line)
+		if incoming {
+			target = fmt.Sprintf("%s%s%s", YELLOW, link.Source, NOCOLOR)
+			location = fmt.Sprintf("line %v", link.Line)
+		}
+
+		text.Println(fmt.Sprintf(
+			" %v %v %v",
+			fmt.Sprintf("%s%s%s", GREY, "•", NOCOLOR),
+			target,
+			fmt.Sprintf("%s%s%s", GREY, location, NOCOLOR),
+		))
+	}
+}
+
+// PrintLinkRewrites, logs the notes which's links are rewritten, with their changed links.
+func PrintLinkRewrites(rewrites []models.LinkRewrite) {
+	for _, rewrite := range rewrites {
+		text.Println(fmt.Sprintf(" %s•%s %s%s%s", GREY, NOCOLOR, YELLOW, rewrite.Title, NOCOLOR))
+
+		for _, change := range rewrite.Changes {
+			text.Println(fmt.Sprintf(
+				"   %v %v %v %v",
+				fmt.Sprintf("%s%v%s", GREY, fmt.Sprintf("line %v", change.Line), NOCOLOR),
+				fmt.Sprintf("%s%s%s", RED, change.From, NOCOLOR),
+				fmt.Sprintf("%s%s%s", GREY, "→", NOCOLOR),
+				fmt.Sprintf("%s%s%s", GREEN, change.To, NOCOLOR),
+			))
+		}
+	}
+}
+
+// PrintGraphReport, logs orphan notes and clusters of linked notes.
+func PrintGraphReport(orphans []string, clusters [][]string) {
+	text.Println(fmt.Sprintf("%sOrphans%s %s%v%s", YELLOW, NOCOLOR, GREY, len(orphans), NOCOLOR))
+	for _, orphan := range orphans {
+		text.Println(fmt.Sprintf(" %s•%s %v", GREY, NOCOLOR, orphan))
+	}
+
+	text.Println(fmt.Sprintf("%sClusters%s %s%v%s", YELLOW, NOCOLOR, GREY, len(clusters), NOCOLOR))
+	for i, cluster := range clusters {
+		text.Println(fmt.Sprintf(
+			" %v %v %v",
+			fmt.Sprintf("%s%v.%s", GREY, i+1, NOCOLOR),
+			strings.Join(cluster, ", "),
+			fmt.Sprintf("%s(%v notes)%s", GREY, len(cluster), NOCOLOR),
+		))
+	}
+}
+
+// PrintDailyNotes, logs given daily notes with their dates and first lines.
+func PrintDailyNotes(notes []models.DailyNote) {
+	for _, note := range notes {
+		text.Println(fmt.Sprintf(
+			" %v %v %v %v",
+			fmt.Sprintf("%s%s%s", GREY, "•", NOCOLOR),
+			fmt.Sprintf("%s%s%s", YELLOW, note.Date.Format("Mon 2006-01-02"), NOCOLOR),
+			note.Node.Title,
+			fmt.Sprintf("%s%s%s", GREY, dailyPreview(note.Node.Body), NOCOLOR),
+		))
+	}
+}
+
+// dailyPreview returns the first line of body's content, that isn't a heading.
+func dailyPreview(body string) string {
+	if _, content, ok := models.SplitFrontMatter(body); ok {
+		body = content
+	}
+
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if runes := []rune(line); len(runes) > 60 {
+			return string(runes[:57]) + "..."
+		}
+
+		return line
+	}
+
+	return ""
+}
+
+// PrintEvent, logs given remote change event.
+func PrintEvent(event models.Event) {
+	c := GREEN
+	switch event.Kind {
+	case models.UpdatedEvent:
+		c = YELLOW
+	case models.RemovedEvent:
+		c = RED
+	}
+
+	text.Println(fmt.Sprintf(
+		" • %v %v %v",
+		fmt.Sprintf("%s%s%s", GREY, event.Time.Local().Format("15:04:05"), NOCOLOR),
+		fmt.Sprintf("%s%s%s", c, event.Kind, NOCOLOR),
+		event.Node.Title,
+	))
+}
+
+// PrintSyncStatus, logs given status of sync daemon.
+func PrintSyncStatus(status models.SyncStatus, running bool) {
+	state := fmt.Sprintf("%s%s%s", RED, "stopped", NOCOLOR)
+	if running {
+		state = fmt.Sprintf("%s%s%s", GREEN, "running", NOCOLOR)
+	}
+
+	format := func(t time.Time) string {
+		if t.IsZero() {
+			return "never"
+		}
+
+		return t.Format(time.RFC1123)
+	}
+
+	fields := [][]string{
+		{"state", state},
+		{"pid", fmt.Sprint(status.PID)},
+		{"remote", status.Remote},
+		{"started at", format(status.StartedAt)},
+		{"last push", format(status.LastPush)},
+		{"last fetch", format(status.LastFetch)},
+		{"pushed", fmt.Sprint(status.Pushed)},
+		{"fetched", fmt.Sprint(status.Fetched)},
+		{"last error", status.LastError},
+	}
+
+	for _, f := range fields {
+		if len(f[1]) == 0 {
+			continue
+		}
+
+		text.Println(fmt.Sprintf(" • %s: %s", fmt.Sprintf("%s%s%s", YELLOW, f[0], NOCOLOR), f[1]))
+	}
+}
+
 // Spinner generates static style nt spinner.
 func Spinner() *spinner.Spinner {
 	s := spinner.New(spinner.CharSets[11], 100*time.Millisecond)
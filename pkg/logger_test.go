@@ -9,7 +9,9 @@ package pkg_test
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/briandowns/spinner"
 	"github.com/fatih/color"
@@ -110,6 +112,24 @@ func TestPrint(t *testing.T) {
 	}
 }
 
+func TestPrintNoteMeta(t *testing.T) {
+	tests := []struct {
+		testName string
+		note     models.Note
+	}{
+		{
+			testName: "should show note metadata properly",
+			note:     models.Note{Title: "note.md", Path: map[string]string{"LOCAL": "~/nt/note.md"}, Body: "hello"},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			pkg.PrintNoteMeta(td.note, "LOCAL")
+		})
+	}
+}
+
 func TestPrintNodes(t *testing.T) {
 	tests := []struct {
 		testName string
@@ -134,6 +154,87 @@ func TestPrintNodes(t *testing.T) {
 	}
 }
 
+func TestPrintNodeTitles(t *testing.T) {
+	tests := []struct {
+		testName string
+		list     []models.Node
+	}{
+		{
+			testName: "should break function",
+			list:     []models.Node{},
+		},
+		{
+			testName: "should show nodes by titles properly",
+			list: []models.Node{
+				{Type: models.FOLDER, Title: "todo/"},
+				{Type: models.FILE, Title: "todo/today.md"},
+			},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			pkg.PrintNodeTitles(td.list)
+		})
+	}
+}
+
+func TestPrintNodesLong(t *testing.T) {
+	tests := []struct {
+		testName string
+		list     []models.Node
+	}{
+		{
+			testName: "should break function",
+			list:     []models.Node{},
+		},
+		{
+			testName: "should show nodes with metadata properly",
+			list: []models.Node{
+				{Type: models.FOLDER, Title: "todo/"},
+				{Type: models.FILE, Title: "todo/today.md", Size: 2048, UpdatedAt: time.Now(), UpdatedBy: "john@macbook"},
+			},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			pkg.PrintNodesLong(td.list)
+		})
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		size     int64
+		expected string
+	}{
+		{size: 0, expected: "0 B"},
+		{size: 1023, expected: "1023 B"},
+		{size: 1024, expected: "1.0 KB"},
+		{size: 1536, expected: "1.5 KB"},
+		{size: 5 * 1024 * 1024, expected: "5.0 MB"},
+	}
+
+	for _, td := range tests {
+		got := pkg.FormatSize(td.size)
+		if got != td.expected {
+			t.Errorf("FormatSize sum was different: Want: %v | Got: %v", td.expected, got)
+		}
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	if got := pkg.FormatTime(time.Time{}); got != "" {
+		t.Errorf("FormatTime of zero time must be empty: Got: %v", got)
+	}
+
+	date := time.Date(2021, 12, 8, 14, 43, 12, 0, time.Local)
+	if got := pkg.FormatTime(date); got != "2021-12-08 14:43:12" {
+		t.Errorf("FormatTime sum was different: Want: %v | Got: %v", "2021-12-08 14:43:12", got)
+	}
+}
+
 func TestPrintSettings(t *testing.T) {
 	tests := []struct {
 		testName string
@@ -170,6 +271,310 @@ func TestPrintErrors(t *testing.T) {
 	}
 }
 
+func TestPrintOperations(t *testing.T) {
+	tests := []struct {
+		testName string
+		ops      []models.Operation
+	}{
+		{
+			testName: "should break function",
+			ops:      []models.Operation{},
+		},
+		{
+			testName: "should show operations properly",
+			ops: []models.Operation{
+				{ID: "1a2b3c4d", Kind: models.CreateOperation, Title: "note.md"},
+				{ID: "5e6f7a8b", Kind: models.RenameOperation, Title: "a.md", NewTitle: "b.md", LastError: "mock", Conflict: true, Attempts: 1},
+			},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			pkg.PrintOperations(td.ops)
+		})
+	}
+}
+
+func TestPrintDiff(t *testing.T) {
+	tests := []struct {
+		testName string
+		lines    []string
+	}{
+		{
+			testName: "should show diff properly",
+			lines:    []string{"  a", "- b", "+ c"},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			pkg.PrintDiff(td.lines)
+		})
+	}
+}
+
+func TestPrintDiagnoses(t *testing.T) {
+	tests := []struct {
+		testName string
+		report   models.DoctorReport
+	}{
+		{
+			testName: "should break function",
+			report:   models.DoctorReport{},
+		},
+		{
+			testName: "should show diagnoses properly",
+			report: models.DoctorReport{
+				Diagnoses: []models.Diagnosis{
+					{Kind: models.OrphanDiagnosis, Path: "nt/todo/", Message: "mock", Suggestion: "mock"},
+					{Kind: models.PathMismatchDiagnosis, Path: "nt/note.md", Message: "mock", Fixed: true},
+				},
+			},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			pkg.PrintDiagnoses(td.report)
+		})
+	}
+}
+
+func TestPrintRetried(t *testing.T) {
+	tests := []struct {
+		testName string
+		labels   []string
+	}{
+		{
+			testName: "should show retried requests properly",
+			labels:   []string{"nt/note.md", "query"},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			pkg.PrintRetried(td.labels)
+		})
+	}
+}
+
+func TestPrintWarnings(t *testing.T) {
+	tests := []struct {
+		testName string
+		warnings []string
+	}{
+		{
+			testName: "should show warnings properly",
+			warnings: []string{"Cannot query changes by modification time"},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			pkg.PrintWarnings(td.warnings)
+		})
+	}
+}
+
+func TestPrintStatus(t *testing.T) {
+	report := models.StatusReport{
+		Remote: "FIREBASE",
+		Nodes: []models.NodeStatus{
+			{Title: "ideas.md", State: models.LocalOnlyState},
+			{Title: "todo/", State: models.IdenticalState},
+			{Title: "todo/today.md", State: models.ModifiedState, Side: models.RemoteSide},
+		},
+	}
+
+	tests := []struct {
+		testName string
+		all      bool
+	}{
+		{testName: "should show differing nodes properly", all: false},
+		{testName: "should show all nodes properly", all: true},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			pkg.PrintStatus(report, td.all)
+		})
+	}
+}
+
+func TestPrintTags(t *testing.T) {
+	tests := []struct {
+		testName string
+		counts   map[string]int
+	}{
+		{testName: "should show nothing for empty counts", counts: map[string]int{}},
+		{testName: "should show tags properly", counts: map[string]int{"work": 3, "ideas": 1, "todo": 3}},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			pkg.PrintTags(td.counts)
+		})
+	}
+}
+
+func TestPrintQueries(t *testing.T) {
+	tests := []struct {
+		testName string
+		queries  map[string]string
+	}{
+		{testName: "should show nothing for empty queries", queries: nil},
+		{testName: "should show queries properly", queries: map[string]string{"oncall": "tag:oncall", "recent": "modified>7d"}},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			pkg.PrintQueries(td.queries)
+		})
+	}
+}
+
+func TestPrintLinks(t *testing.T) {
+	links := []models.Link{
+		{Kind: models.WikiLink, Source: "journal.md", Target: "todo", Line: 3, Title: "work/todo.md"},
+		{Kind: models.MarkdownLink, Source: "journal.md", Target: "work/todo.md", Line: 4, Title: "work/todo.md"},
+		{Kind: models.WikiLink, Source: "journal.md", Target: "missing", Line: 5},
+	}
+
+	tests := []struct {
+		testName string
+		incoming bool
+	}{
+		{testName: "should show outgoing links properly", incoming: false},
+		{testName: "should show incoming links properly", incoming: true},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			pkg.PrintLinks(links, td.incoming)
+		})
+	}
+}
+
+func TestPrintLinkRewrites(t *testing.T) {
+	tests := []struct {
+		testName string
+		rewrites []models.LinkRewrite
+	}{
+		{testName: "should show nothing if there are no rewrites", rewrites: []models.LinkRewrite{}},
+		{
+			testName: "should show rewritten links properly",
+			rewrites: []models.LinkRewrite{
+				{
+					Title: "journal.md",
+					Changes: []models.LinkChange{
+						{Line: 1, From: "todo", To: "tasks"},
+						{Line: 4, From: "work/todo.md", To: "work/tasks.md"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			pkg.PrintLinkRewrites(td.rewrites)
+		})
+	}
+}
+
+func TestPrintGraphReport(t *testing.T) {
+	tests := []struct {
+		testName string
+		orphans  []string
+		clusters [][]string
+	}{
+		{testName: "should show empty report properly", orphans: []string{}, clusters: [][]string{}},
+		{
+			testName: "should show orphans and clusters properly",
+			orphans:  []string{"ideas.md"},
+			clusters: [][]string{{"journal.md", "work/todo.md"}, {"a.md", "b.md"}},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			pkg.PrintGraphReport(td.orphans, td.clusters)
+		})
+	}
+}
+
+func TestPrintDailyNotes(t *testing.T) {
+	date := time.Date(2026, 10, 18, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		testName string
+		notes    []models.DailyNote
+	}{
+		{testName: "should show nothing if there are no daily notes", notes: []models.DailyNote{}},
+		{
+			testName: "should show daily notes properly",
+			notes: []models.DailyNote{
+				{Date: date, Node: models.Node{Title: "journal/2026/10/2026-10-18.md", Body: "---\ntags: [log]\n---\n# 2026-10-18\nFixed the deploy"}},
+				{Date: date.AddDate(0, 0, -1), Node: models.Node{Title: "journal/2026/10/2026-10-17.md", Body: strings.Repeat("long ", 20)}},
+				{Date: date.AddDate(0, 0, -2), Node: models.Node{Title: "journal/2026/10/2026-10-16.md"}},
+			},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			pkg.PrintDailyNotes(td.notes)
+		})
+	}
+}
+
+func TestPrintEvent(t *testing.T) {
+	tests := []struct {
+		testName string
+		event    models.Event
+	}{
+		{
+			testName: "should show created event properly",
+			event:    models.Event{Kind: models.CreatedEvent, Node: models.Node{Title: "note.md"}},
+		},
+		{
+			testName: "should show removed event properly",
+			event:    models.Event{Kind: models.RemovedEvent, Node: models.Node{Title: "todo/"}},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			pkg.PrintEvent(td.event)
+		})
+	}
+}
+
+func TestPrintSyncStatus(t *testing.T) {
+	tests := []struct {
+		testName string
+		status   models.SyncStatus
+		running  bool
+	}{
+		{
+			testName: "should show stopped status properly",
+			status:   models.SyncStatus{},
+		},
+		{
+			testName: "should show running status properly",
+			status:   models.SyncStatus{PID: 4242, Remote: "FIREBASE", LastError: "mock"},
+			running:  true,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			pkg.PrintSyncStatus(td.status, td.running)
+		})
+	}
+}
+
 func TestSpinner(t *testing.T) {
 	got := pkg.Spinner()
 
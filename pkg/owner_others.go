@@ -0,0 +1,18 @@
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package pkg
+
+import "os"
+
+// FileOwner returns the name of user, that owns the file of stat [info].
+// Owner of file isn't available on this platform, so result is empty.
+func FileOwner(info os.FileInfo) string {
+	return ""
+}
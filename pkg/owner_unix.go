@@ -0,0 +1,33 @@
+//go:build linux || darwin
+// +build linux darwin
+
+//
+// Copyright 2021-present Insolite. All rights reserved.
+// Use of this source code is governed by Apache 2.0 license
+// that can be found in the LICENSE file.
+//
+
+package pkg
+
+import (
+	"os"
+	"os/user"
+	"strconv"
+	"syscall"
+)
+
+// FileOwner returns the name of user, that owns the file of stat [info].
+// Falls back to the user id, if user couldn't be looked up.
+func FileOwner(info os.FileInfo) string {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return ""
+	}
+
+	uid := strconv.FormatUint(uint64(stat.Uid), 10)
+	if u, err := user.LookupId(uid); err == nil {
+		return u.Username
+	}
+
+	return uid
+}
@@ -7,8 +7,15 @@
 package pkg
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"os/user"
+	"path"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -87,10 +94,87 @@ func ReadBody(path string) (*string, error) {
 	return &res, nil
 }
 
+// Hash, generates a content hash(SHA-256) of given body.
+// Used to compare notes without transferring their bodies.
+func Hash(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
+
+// tempTitleRegex matches the [time.Now().String()] suffix,
+// that's appended to titles of temporary notes.
+var tempTitleRegex = regexp.MustCompile(
+	`\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d+)? [+-]\d{4} [A-Za-z0-9+-]+( m=[+-]\d+\.\d+)?`,
+)
+
+// Author generates the "user@device" signature of current machine's user.
+// Used to represent the author of the last modification of nodes.
+func Author() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	return Username() + "@" + host
+}
+
+// ReadPiped reads all data of [stdin], if it's piped(or redirected from a file).
+// Result is false, if [stdin] is an interactive terminal or any other kind of
+// file(like a socket), which could block reading without an actual input.
+func ReadPiped(stdin io.Reader) (string, bool) {
+	if f, ok := stdin.(*os.File); ok {
+		info, err := f.Stat()
+		if err != nil || (info.Mode()&os.ModeNamedPipe == 0 && !info.Mode().IsRegular()) {
+			return "", false
+		}
+	}
+
+	return ReadStdin(stdin)
+}
+
+// ReadStdin reads all data of [stdin], without checking if it's piped.
+// Used when input is explicitly requested, like: nt create note.md --content -
+func ReadStdin(stdin io.Reader) (string, bool) {
+	if stdin == nil {
+		return "", false
+	}
+
+	data, err := io.ReadAll(stdin)
+	if err != nil {
+		return "", false
+	}
+
+	return string(data), true
+}
+
+// Username returns the name of current machine's user.
+func Username() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+
+	return "unknown"
+}
+
+// IsTempTitle checks if given title belongs to a temporary note,
+// that's created locally while editing remote notes or settings.
+func IsTempTitle(title string) bool {
+	return tempTitleRegex.MatchString(title)
+}
+
 // ListDir, reads all files from given-path directory. and returns:
 // 1. a slice of exact names of files and folders + subfiles and subfolders.
 // 2. nested hierarchy of first array
+//
+// Nodes are ignored by gitignore-style [ignore] patterns, and by the
+// rules of [models.IgnoreFileName] files of listed folders.
 func ListDir(root, currentPath, typ string, ignore []string, level int) ([]string, [][]string, error) {
+	rules := append(ParseIgnore("", ignore), ReadIgnore(root, strings.Replace(currentPath, root, "", -1))...)
+	return listDir(root, currentPath, typ, rules, level)
+}
+
+// listDir is the recursive implementation of [ListDir].
+func listDir(root, currentPath, typ string, rules IgnoreRules, level int) ([]string, [][]string, error) {
 	var res []string
 	var pretty [][]string
 
@@ -116,7 +200,7 @@ func ListDir(root, currentPath, typ string, ignore []string, level int) ([]strin
 		}
 
 		// Ignore the current file, if it is ignorable.
-		if IsIgnorable(f.Name(), ignore) || len(p) == 0 {
+		if len(p) == 0 || rules.Match(p, f.IsDir()) {
 			continue
 		}
 
@@ -126,7 +210,9 @@ func ListDir(root, currentPath, typ string, ignore []string, level int) ([]strin
 		}
 
 		if f.IsDir() {
-			sub, subPretty, subErr := ListDir(root, path, typ, ignore, level+1)
+			subRules := append(append(IgnoreRules{}, rules...), ReadIgnore(root, p)...)
+
+			sub, subPretty, subErr := listDir(root, path, typ, subRules, level+1)
 			if subErr != nil {
 				continue
 			}
@@ -168,6 +254,44 @@ func IsIgnorable(s string, ignore []string) bool {
 	return false
 }
 
+// MatchPath checks if [title] is selected by one of [patterns].
+// Patterns are matched against the title and each of its parent folders,
+// so "work/" selects the folder and everything under it. Glob syntax of
+// [path.Match] is supported, e.g. "journal/2026-*". Empty patterns select everything.
+func MatchPath(title string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	segments := strings.Split(strings.Trim(title, "/"), "/")
+	for _, pattern := range patterns {
+		pattern = strings.Trim(pattern, "/")
+
+		for i := range segments {
+			if matched, _ := path.Match(pattern, strings.Join(segments[:i+1], "/")); matched {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// EscapePattern escapes the special characters of path pattern at [title],
+// to generate a pattern that matches only the given title.
+func EscapePattern(title string) string {
+	var b strings.Builder
+	for _, r := range title {
+		if strings.ContainsRune(`*?[]\`, r) {
+			b.WriteRune('\\')
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
 // OpenViaEditor opens file in custom(appropriate from settings) from given path.
 func OpenViaEditor(filepath string, stdargs models.StdArgs, settings models.Settings) error {
 	// Look editor's execution path from current running machine.
@@ -253,5 +377,12 @@ func IsSettingsUpdated(old, current models.Settings) bool {
 		NormalizePath(old.NotesPath) != NormalizePath(current.NotesPath) ||
 		old.FirebaseProjectID != current.FirebaseProjectID ||
 		old.FirebaseAccountKey != current.FirebaseAccountKey ||
-		old.FirebaseCollection != current.FirebaseCollection
+		old.FirebaseCollection != current.FirebaseCollection ||
+		old.FireLayout() != current.FireLayout() ||
+		old.FireRetryPolicy() != current.FireRetryPolicy() ||
+		strings.Join(old.Ignore, "\n") != strings.Join(current.Ignore, "\n") ||
+		fmt.Sprint(old.Queries) != fmt.Sprint(current.Queries) ||
+		old.JournalRoot() != current.JournalRoot() ||
+		old.JournalLayout() != current.JournalLayout() ||
+		old.JournalTemplate != current.JournalTemplate
 }
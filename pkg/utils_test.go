@@ -8,8 +8,12 @@ package pkg_test
 
 import (
 	"errors"
+	"io"
 	"os"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/insolite-dev/nt/lib/models"
 	"github.com/insolite-dev/nt/pkg"
@@ -226,6 +230,190 @@ func TestReadBody(t *testing.T) {
 	}
 }
 
+func TestHash(t *testing.T) {
+	tests := []struct {
+		body     string
+		expected string
+	}{
+		{
+			body:     "",
+			expected: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		},
+		{
+			body:     "hello",
+			expected: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		},
+	}
+
+	for _, td := range tests {
+		got := pkg.Hash(td.body)
+		if got != td.expected {
+			t.Errorf("Hash sum was different: Want: %v | Got: %v", td.expected, got)
+		}
+	}
+}
+
+func TestAuthor(t *testing.T) {
+	got := pkg.Author()
+	if parts := strings.Split(got, "@"); len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		t.Errorf("Author must be formatted as user@device: Got: %v", got)
+	}
+}
+
+func TestReadPiped(t *testing.T) {
+	file := t.TempDir() + "/input.txt"
+	if err := os.WriteFile(file, []byte("piped body"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	redirected, _ := os.Open(file)
+	defer redirected.Close()
+
+	dir, _ := os.Open(t.TempDir())
+	defer dir.Close()
+
+	tests := []struct {
+		testName string
+		stdin    io.Reader
+		expected string
+		ok       bool
+	}{
+		{testName: "should not read nil input", stdin: nil, expected: "", ok: false},
+		{testName: "should read reader input", stdin: strings.NewReader("from reader"), expected: "from reader", ok: true},
+		{testName: "should read redirected file", stdin: redirected, expected: "piped body", ok: true},
+		{testName: "should not read non-piped file", stdin: dir, expected: "", ok: false},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			got, ok := pkg.ReadPiped(td.stdin)
+			if got != td.expected || ok != td.ok {
+				t.Errorf("ReadPiped sum was different: Want: %v, %v | Got: %v, %v", td.expected, td.ok, got, ok)
+			}
+		})
+	}
+}
+
+func TestReadStdin(t *testing.T) {
+	tests := []struct {
+		testName string
+		stdin    io.Reader
+		expected string
+		ok       bool
+	}{
+		{testName: "should not read nil input", stdin: nil, expected: "", ok: false},
+		{testName: "should read reader input", stdin: strings.NewReader("from reader"), expected: "from reader", ok: true},
+	}
+
+	for _, td := range tests {
+		t.Run(td.testName, func(t *testing.T) {
+			got, ok := pkg.ReadStdin(td.stdin)
+			if got != td.expected || ok != td.ok {
+				t.Errorf("ReadStdin sum was different: Want: %v, %v | Got: %v, %v", td.expected, td.ok, got, ok)
+			}
+		})
+	}
+}
+
+func TestUsername(t *testing.T) {
+	if got := pkg.Username(); len(got) == 0 || strings.Contains(got, "@") {
+		t.Errorf("Username must be a non-empty user name: Got: %v", got)
+	}
+}
+
+func TestCreationTime(t *testing.T) {
+	path := t.TempDir() + "/note.md"
+	if err := os.WriteFile(path, []byte("body"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	info, _ := os.Stat(path)
+	got := pkg.CreationTime(path, info)
+	if got.IsZero() || got.After(time.Now().Add(time.Second)) {
+		t.Errorf("CreationTime sum was invalid: Got: %v", got)
+	}
+}
+
+func TestFileOwner(t *testing.T) {
+	path := t.TempDir() + "/note.md"
+	if err := os.WriteFile(path, []byte("body"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	info, _ := os.Stat(path)
+	if got := pkg.FileOwner(info); (runtime.GOOS == "linux" || runtime.GOOS == "darwin") && got != pkg.Username() {
+		t.Errorf("FileOwner sum was different: Want: %v | Got: %v", pkg.Username(), got)
+	}
+}
+
+func TestIsTempTitle(t *testing.T) {
+	tests := []struct {
+		title    string
+		expected bool
+	}{
+		{title: "note.md", expected: false},
+		{title: "2022-01-01.md", expected: false},
+		{title: "note.md2022-01-01 10:20:30.123456789 +0400 +04 m=+0.012345678", expected: true},
+		{title: "2022-01-01 10:20:30.123456789 +0000 UTC m=+0.012345678settings.json", expected: true},
+		{title: "note.md" + time.Now().String(), expected: true},
+	}
+
+	for _, td := range tests {
+		got := pkg.IsTempTitle(td.title)
+		if got != td.expected {
+			t.Errorf("Sum of IsTempTitle(%v) was different: Want: %v | Got: %v", td.title, td.expected, got)
+		}
+	}
+}
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		title    string
+		patterns []string
+		expected bool
+	}{
+		{title: "note.md", patterns: nil, expected: true},
+		{title: "note.md", patterns: []string{"note.md"}, expected: true},
+		{title: "work/", patterns: []string{"work/"}, expected: true},
+		{title: "work/todo/today.md", patterns: []string{"work/"}, expected: true},
+		{title: "work/todo/today.md", patterns: []string{"work"}, expected: true},
+		{title: "workshop.md", patterns: []string{"work/"}, expected: false},
+		{title: "journal/2026-01-01.md", patterns: []string{"journal/2026-*"}, expected: true},
+		{title: "journal/2025-12-31.md", patterns: []string{"journal/2026-*"}, expected: false},
+		{title: "private/secret.md", patterns: []string{"work/", "journal/*"}, expected: false},
+		{title: "journal/", patterns: []string{"journal/*"}, expected: false},
+	}
+
+	for _, td := range tests {
+		got := pkg.MatchPath(td.title, td.patterns)
+		if got != td.expected {
+			t.Errorf("Sum of MatchPath(%v, %v) was different: Want: %v | Got: %v", td.title, td.patterns, td.expected, got)
+		}
+	}
+}
+
+func TestEscapePattern(t *testing.T) {
+	tests := []struct {
+		title    string
+		expected string
+	}{
+		{title: "work/todo.md", expected: "work/todo.md"},
+		{title: "ideas [draft]*?.md", expected: `ideas \[draft\]\*\?.md`},
+		{title: `back\slash.md`, expected: `back\\slash.md`},
+	}
+
+	for _, td := range tests {
+		got := pkg.EscapePattern(td.title)
+		if got != td.expected {
+			t.Errorf("Sum of EscapePattern(%v) was different: Want: %v | Got: %v", td.title, td.expected, got)
+		}
+
+		if !pkg.MatchPath(td.title, []string{got}) {
+			t.Errorf("Escaped pattern %v must match its title %v", got, td.title)
+		}
+	}
+}
+
 func TestOpenViaEditor(t *testing.T) {
 	type utilArgs struct {
 		filename       string
@@ -430,6 +618,24 @@ func TestIsUpdated(t *testing.T) {
 			current:  models.Settings{Editor: models.DefaultEditor},
 			expected: true,
 		},
+		{
+			testname: "should check properly if ignore patterns are updated",
+			old:      models.Settings{Editor: models.DefaultEditor},
+			current:  models.Settings{Editor: models.DefaultEditor, Ignore: []string{"*.tmp"}},
+			expected: true,
+		},
+		{
+			testname: "should check properly if saved queries are updated",
+			old:      models.Settings{Editor: models.DefaultEditor, Queries: map[string]string{"todo": "tag:todo"}},
+			current:  models.Settings{Editor: models.DefaultEditor, Queries: map[string]string{"todo": "tag:todo OR tag:later"}},
+			expected: true,
+		},
+		{
+			testname: "should check properly if journal settings are updated",
+			old:      models.Settings{Editor: models.DefaultEditor, JournalFolder: "journal/"},
+			current:  models.Settings{Editor: models.DefaultEditor, JournalFolder: "logs", JournalTemplate: "daily"},
+			expected: true,
+		},
 	}
 
 	for _, td := range tests {